@@ -0,0 +1,76 @@
+// Copyright 2015 Randall Farmer. All rights reserved.
+
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+package sorts
+
+import (
+	"sort"
+	"sync/atomic"
+)
+
+// ByUint64Budget is ByUint64, except that once the sort has made more than
+// maxSwaps calls to data.Swap, it stops early and returns false, leaving
+// data partly sorted, so a caller short on time can fall back to something
+// cheaper.  Swaps are tallied each time a task hands off a bucket and when
+// it finishes, and the budget is checked before each task starts, so a
+// sort can overrun by up to about one radix pass over the range being
+// sorted.  Data found already sorted costs no swaps; reversed data costs
+// half its length.
+func ByUint64Budget(data Uint64Interface, maxSwaps int64) (done bool) {
+	l := data.Len()
+	b := &budget{left: maxSwaps}
+	counted := swapCounter{data, new(int)}
+	if l < qSortCutoff {
+		qSortUint64(counted, 0, l)
+		return b.spend(counted.swaps)
+	}
+	presorted := uint64Presorted(counted, 0, l)
+	if !b.spend(counted.swaps) {
+		return false
+	}
+	if !presorted {
+		sorter, t := uint64Sorter(data, 0, l, nil)
+		parallelSort(data, b.wrap(sorter), t)
+		if atomic.LoadInt32(&b.skipped) != 0 {
+			return false
+		}
+	}
+
+	// check results!
+	checkUint64(data, 0, l)
+	return true
+}
+
+// budget is a swap allowance shared by a sort's goroutines.
+type budget struct {
+	left    int64 // swaps left; negative once over budget
+	skipped int32 // set when a task was dropped for being over budget
+}
+
+// spend takes *swaps from the budget, zeroes *swaps, and says whether
+// there's anything left.
+func (b *budget) spend(swaps *int) bool {
+	left := atomic.AddInt64(&b.left, -int64(*swaps))
+	*swaps = 0
+	return left >= 0
+}
+
+// wrap wraps sorter to count its swaps and to drop tasks once the budget
+// is spent.  As with canceler, dropped tasks generate no subtasks.
+func (b *budget) wrap(sorter sortFunc) sortFunc {
+	return func(data sort.Interface, t task, sortRange func(task)) {
+		if atomic.LoadInt64(&b.left) < 0 {
+			atomic.StoreInt32(&b.skipped, 1)
+			return
+		}
+		// a task runs in one goroutine, so it can count without atomics
+		counted := swapCounter{data.(Uint64Interface), new(int)}
+		sorter(counted, t, func(sub task) {
+			b.spend(counted.swaps)
+			sortRange(sub)
+		})
+		b.spend(counted.swaps)
+	}
+}
@@ -0,0 +1,47 @@
+// Copyright 2015 Randall Farmer. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sorts_test
+
+import (
+	"math/rand"
+	"testing"
+
+	. "github.com/twotwotwo/sorts"
+	. "github.com/twotwotwo/sorts/sortutil"
+)
+
+func TestByUint64Budget(t *testing.T) {
+	orig := make([]uint64, 100000)
+	for i := range orig {
+		orig[i] = uint64(rand.Int63())
+	}
+	data := append([]uint64(nil), orig...)
+	swaps := ByUint64Stats(Uint64Slice(data)).Swaps
+
+	data = append(data[:0], orig...)
+	if !ByUint64Budget(Uint64Slice(data), int64(swaps)) || !Uint64sAreSorted(data) {
+		t.Errorf("didn't finish with a budget of the %d swaps it takes", swaps)
+	}
+
+	data = append(data[:0], orig...)
+	if ByUint64Budget(Uint64Slice(data), int64(swaps/2)) {
+		t.Errorf("finished with half the swaps it takes")
+	}
+	Uint64s(data)
+	sorted := append([]uint64(nil), orig...)
+	Uint64s(sorted)
+	for i := range data {
+		if data[i] != sorted[i] {
+			t.Fatalf("stopping early lost data")
+		}
+	}
+
+	if !ByUint64Budget(Uint64Slice(sorted), 0) {
+		t.Errorf("sorted data didn't fit in a budget of 0")
+	}
+	if ByUint64Budget(Uint64Slice([]uint64{3, 2, 1}), 0) {
+		t.Errorf("3 items sorted with no swaps")
+	}
+}
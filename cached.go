@@ -0,0 +1,41 @@
+// Copyright 2015 Randall Farmer. All rights reserved.
+
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+package sorts
+
+// ByStringCached is ByString for collections whose Key is expensive, e.g.
+// computed rather than stored.  ByString calls Key at least twice per item
+// per byte of common prefix it looks at; ByStringCached calls it once per
+// item, radix sorts copies of the keys alongside the items' original
+// positions, then moves data into place, using Less to order items with
+// equal keys as ByString does.  That costs about 24 bytes of extra memory
+// per item, plus whatever the keys themselves take.
+func ByStringCached(data StringInterface) {
+	l := data.Len()
+	if l < qSortCutoff {
+		qSort(data, 0, l)
+		return
+	}
+	sp := stringPerm{make([]string, l), make([]int, l)}
+	for i := range sp.keys {
+		sp.keys[i] = data.Key(i)
+		sp.perm[i] = i
+	}
+	parallelSort(sp, radixSortString, task{offs: 0, pos: 0, end: l})
+	keys := sp.keys
+	applyPerm(data, sp.perm)
+	a := 0
+	for b := 1; b <= l; b++ {
+		if b == l || keys[b] != keys[a] {
+			if b-a > 1 {
+				qSortEqualKeyRange(data, a, b)
+			}
+			a = b
+		}
+	}
+
+	// check results!
+	checkString(data, 0, l)
+}
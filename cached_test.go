@@ -0,0 +1,59 @@
+// Copyright 2015 Randall Farmer. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sorts_test
+
+import (
+	"math/rand"
+	"strconv"
+	"testing"
+
+	. "github.com/twotwotwo/sorts"
+)
+
+// hexInts has a computed string key, and counts calls to Key.
+type hexInts struct {
+	data  []int
+	calls int
+}
+
+func (h *hexInts) Len() int           { return len(h.data) }
+func (h *hexInts) Less(i, j int) bool { return h.hex(i) < h.hex(j) }
+func (h *hexInts) Swap(i, j int)      { h.data[i], h.data[j] = h.data[j], h.data[i] }
+func (h *hexInts) Key(i int) string {
+	h.calls++
+	return h.hex(i)
+}
+func (h *hexInts) hex(i int) string { return strconv.FormatInt(int64(h.data[i]), 16) }
+
+func TestByStringCached(t *testing.T) {
+	for _, size := range []int{0, 10, 1000, 100000} {
+		h := &hexInts{data: make([]int, size)}
+		for i := range h.data {
+			h.data[i] = rand.Intn(1 << 20)
+		}
+		varyQSortCutoff(func() {
+			rand.Shuffle(len(h.data), func(i, j int) { h.Swap(i, j) })
+			h.calls = 0
+			ByStringCached(h)
+			for i := 1; i < len(h.data); i++ {
+				if h.Less(i, i-1) {
+					t.Fatalf("size %d: not sorted at %d", size, i)
+				}
+			}
+		})
+	}
+
+	// Key is called once per item, plus by the check if it's on
+	h := &hexInts{data: make([]int, 100000)}
+	for i := range h.data {
+		h.data[i] = rand.Intn(1 << 20)
+	}
+	Verify = false
+	defer func() { Verify = true }()
+	ByStringCached(h)
+	if h.calls != len(h.data) {
+		t.Errorf("ByStringCached called Key %d times for %d items", h.calls, len(h.data))
+	}
+}
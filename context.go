@@ -0,0 +1,83 @@
+// Copyright 2015 Randall Farmer. All rights reserved.
+
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+package sorts
+
+import (
+	"context"
+	"sort"
+	"sync/atomic"
+)
+
+// ByUint64Context is ByUint64, except that if ctx is canceled or times out
+// before the sort is done, it stops early and returns ctx.Err(), leaving
+// data partly sorted.  Cancellation is checked before each bucket or other
+// task is sorted, so a canceled sort can take a little while to return,
+// but it never leaves goroutines behind.
+func ByUint64Context(ctx context.Context, data Uint64Interface) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	l := data.Len()
+	if l < qSortCutoff {
+		qSortUint64(data, 0, l)
+		return nil
+	}
+
+	c := newCanceler(ctx)
+	sorter, t := uint64Sorter(data, 0, l, nil)
+	parallelSort(data, c.wrap(sorter), t)
+	if err := c.finish(); err != nil {
+		return err
+	}
+
+	// check results if we radix sorted!
+	checkUint64(data, 0, l)
+	return nil
+}
+
+// canceler lets a sort's tasks poll a context for cancellation.  Polling
+// is a non-blocking receive on ctx.Done(), which doesn't lock, and sees a
+// cancel as soon as the cancel func returns.
+type canceler struct {
+	ctx     context.Context
+	done    <-chan struct{} // nil if ctx can't be canceled
+	skipped int32           // set when a task was dropped because of it
+}
+
+func newCanceler(ctx context.Context) *canceler {
+	return &canceler{ctx: ctx, done: ctx.Done()}
+}
+
+// canceled says whether the context is done.
+func (c *canceler) canceled() bool {
+	select {
+	case <-c.done:
+		return true
+	default:
+		return false
+	}
+}
+
+// wrap wraps sorter to drop tasks once the context is done.  Subtasks
+// aren't generated for dropped tasks, so the workers run out of work fast.
+func (c *canceler) wrap(sorter sortFunc) sortFunc {
+	return func(data sort.Interface, t task, sortRange func(task)) {
+		if c.canceled() {
+			atomic.StoreInt32(&c.skipped, 1)
+			return
+		}
+		sorter(data, t, sortRange)
+	}
+}
+
+// finish returns the context's error if any task was dropped.  A sort that
+// happened to complete as its context was canceled returns nil.
+func (c *canceler) finish() error {
+	if atomic.LoadInt32(&c.skipped) != 0 {
+		return c.ctx.Err()
+	}
+	return nil
+}
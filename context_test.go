@@ -0,0 +1,69 @@
+// Copyright 2015 Randall Farmer. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sorts_test
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+
+	. "github.com/twotwotwo/sorts"
+	. "github.com/twotwotwo/sorts/sortutil"
+)
+
+// cancelingUint64s cancels a context after a certain number of swaps.
+type cancelingUint64s struct {
+	Uint64Slice
+	swaps  *int
+	cancel func()
+}
+
+func (c cancelingUint64s) Swap(i, j int) {
+	*c.swaps--
+	if *c.swaps == 0 {
+		c.cancel() // the sort's next task sees this
+	}
+	c.Uint64Slice.Swap(i, j)
+}
+
+func TestByUint64Context(t *testing.T) {
+	data := make([]uint64, 100000)
+	for i := range data {
+		data[i] = uint64(rand.Int63())
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := ByUint64Context(ctx, Uint64Slice(data)); err != context.Canceled {
+		t.Errorf("sort with canceled context returned %v", err)
+	}
+
+	defer func(old int) { MaxProcs = old }(MaxProcs)
+	MaxProcs = 1 // so swaps happen in a predictable order
+	ctx, cancel = context.WithCancel(context.Background())
+	swaps := 1000
+	err := ByUint64Context(ctx, cancelingUint64s{Uint64Slice(data), &swaps, cancel})
+	if err != context.Canceled {
+		t.Errorf("sort canceled midway returned %v", err)
+	}
+	if Uint64sAreSorted(data) {
+		t.Errorf("sort canceled midway finished anyway")
+	}
+
+	if err := ByUint64Context(context.Background(), Uint64Slice(data)); err != nil {
+		t.Errorf("uncanceled sort returned %v", err)
+	}
+	if !Uint64sAreSorted(data) {
+		t.Errorf("uncanceled sort didn't sort")
+	}
+
+	small := make([]uint64, 50)
+	for i := range small {
+		small[i] = uint64(rand.Int63())
+	}
+	if err := ByUint64Context(context.Background(), Uint64Slice(small)); err != nil || !Uint64sAreSorted(small) {
+		t.Errorf("small sort returned %v, sorted %v", err, Uint64sAreSorted(small))
+	}
+}
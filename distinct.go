@@ -0,0 +1,95 @@
+// Copyright 2015 Randall Farmer. All rights reserved.
+
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+package sorts
+
+import "bytes"
+
+// ByUint64Distinct sorts data like ByUint64 and returns how many distinct
+// keys it has.  It counts them in the same pass over the data that checks
+// the sort (see Verify), so it costs little more than the sort.
+func ByUint64Distinct(data Uint64Interface) int {
+	l := data.Len()
+	byUint64Range(data, 0, l, parallelSort, &Options{noCheck: true})
+	if l == 0 {
+		return 0
+	}
+	n, prev := 1, data.Key(0)
+	for i := 1; i < l; i++ {
+		k := data.Key(i)
+		if k != prev {
+			n++
+			prev = k
+		}
+		if Verify && data.Less(i, i-1) {
+			checkUint64(data, i-1, i+1)
+		}
+	}
+	return n
+}
+
+// ByInt64Distinct is ByUint64Distinct for int64 keys.
+func ByInt64Distinct(data Int64Interface) int {
+	l := data.Len()
+	byInt64Range(data, 0, l, parallelSort, &Options{noCheck: true})
+	if l == 0 {
+		return 0
+	}
+	n, prev := 1, data.Key(0)
+	for i := 1; i < l; i++ {
+		k := data.Key(i)
+		if k != prev {
+			n++
+			prev = k
+		}
+		if Verify && data.Less(i, i-1) {
+			checkInt64(data, i-1, i+1)
+		}
+	}
+	return n
+}
+
+// ByStringDistinct is ByUint64Distinct for string keys, comparing whole
+// keys to count them.
+func ByStringDistinct(data StringInterface) int {
+	l := data.Len()
+	byStringRange(data, 0, l, parallelSort, &Options{noCheck: true})
+	if l == 0 {
+		return 0
+	}
+	n, prev := 1, data.Key(0)
+	for i := 1; i < l; i++ {
+		k := data.Key(i)
+		if k != prev {
+			n++
+			prev = k
+		}
+		if Verify && data.Less(i, i-1) {
+			checkString(data, i-1, i+1)
+		}
+	}
+	return n
+}
+
+// ByBytesDistinct is ByStringDistinct for []byte keys.
+func ByBytesDistinct(data BytesInterface) int {
+	l := data.Len()
+	byBytesRange(data, 0, l, parallelSort, &Options{noCheck: true})
+	if l == 0 {
+		return 0
+	}
+	n, prev := 1, data.Key(0)
+	for i := 1; i < l; i++ {
+		k := data.Key(i)
+		if !bytes.Equal(k, prev) {
+			n++
+			prev = k
+		}
+		if Verify && data.Less(i, i-1) {
+			checkBytes(data, i-1, i+1)
+		}
+	}
+	return n
+}
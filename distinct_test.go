@@ -0,0 +1,59 @@
+// Copyright 2015 Randall Farmer. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sorts_test
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+
+	. "github.com/twotwotwo/sorts"
+	. "github.com/twotwotwo/sorts/sortutil"
+)
+
+func TestDistinct(t *testing.T) {
+	for _, n := range []int{0, 1, 100, 100000} {
+		a := make([]int, n)
+		seen := map[int]bool{}
+		for i := range a {
+			a[i] = rand.Intn(n/2+1) - n/4
+			seen[a[i]] = true
+		}
+		asBytes, asStrings, asUints := convertInts(a)
+		asUint64s := make([]uint64, len(asUints))
+		for i, v := range asUints {
+			asUint64s[i] = uint64(v)
+		}
+		want := len(seen)
+		if got := ByInt64Distinct(IntSlice(a)); got != want || !sort.IntsAreSorted(a) {
+			t.Errorf("n=%d: ByInt64Distinct got %d, want %d", n, got, want)
+		}
+		if got := ByUint64Distinct(Uint64Slice(asUint64s)); got != want || !Uint64sAreSorted(asUint64s) {
+			t.Errorf("n=%d: ByUint64Distinct got %d, want %d", n, got, want)
+		}
+		if got := ByStringDistinct(StringSlice(asStrings)); got != want || !sort.StringsAreSorted(asStrings) {
+			t.Errorf("n=%d: ByStringDistinct got %d, want %d", n, got, want)
+		}
+		if got := ByBytesDistinct(BytesSlice(asBytes)); got != want || !BytesAreSorted(asBytes) {
+			t.Errorf("n=%d: ByBytesDistinct got %d, want %d", n, got, want)
+		}
+	}
+}
+
+func TestDistinctCheck(t *testing.T) {
+	if !Checking() {
+		return
+	}
+	mustPanic(t, "miskeyedInts", func() {
+		forceRadix(func() {
+			ByInt64Distinct(miskeyedInts{IntSlice{1, 2, 3}})
+		})
+	})
+	mustPanic(t, "unsortableStrings", func() {
+		forceRadix(func() {
+			ByStringDistinct(unsortableStrings{StringSlice{"", "", ""}})
+		})
+	})
+}
@@ -6,14 +6,17 @@
 
 package sorts
 
-import "sort"
+import (
+	"sort"
+	"sync"
+)
 
 func Heapsort(data sort.Interface) {
 	heapSort(data, 0, data.Len())
 }
 
 func GuessIntShift(data Int64Interface, l int) uint {
-	return guessIntShift(intwrapper{data}, l)
+	return guessIntShift(intwrapper{data}, 0, l)
 }
 
 func SetQSortCutoff(i int) int {
@@ -28,6 +31,33 @@ func SetMinOffload(i int) int {
 	return orig
 }
 
+func SetMinParallel(i int) int {
+	orig := minParallel
+	minParallel = i
+	return orig
+}
+
+// Limits returns the MinParallel and MinOffload o's sorts will use.
+func Limits(o *Options) (minParallel, minOffload int) {
+	return o.minParallel(), o.minOffload()
+}
+
 func Checking() bool {
-	return true
+	return Verify
+}
+
+func SetBufferRatio(r float32) float32 {
+	orig := bufferRatio
+	bufferRatio = r
+	return orig
+}
+
+func QueueLen(p *Pool) int {
+	return cap(p.work)
+}
+
+// ForgetAutoTune makes the next AutoTune call measure again.
+func ForgetAutoTune() {
+	tuneOnce = sync.Once{}
+	tuned = Tuning{}
 }
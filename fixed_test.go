@@ -0,0 +1,102 @@
+// Copyright 2015 Randall Farmer. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sorts_test
+
+import (
+	"encoding/binary"
+	"math/rand"
+	"testing"
+
+	. "github.com/twotwotwo/sorts"
+	. "github.com/twotwotwo/sorts/sortutil"
+)
+
+// lessCounter counts Less calls.
+type lessCounter struct {
+	BytesSlice
+	less *int
+}
+
+func (l lessCounter) Less(i, j int) bool {
+	*l.less++
+	return l.BytesSlice.Less(i, j)
+}
+
+func TestByBytesFixed(t *testing.T) {
+	defer func(old bool) { Verify = old }(Verify)
+	Verify = false // it calls Less
+	const keyLen = 64
+	// key i is all zeroes except byte i, so each radix pass peels off one
+	// key and it takes keyLen levels to finish; shuffled so the sort
+	// doesn't find them already in order
+	keys := func() BytesSlice {
+		b := make(BytesSlice, keyLen)
+		for i := range b {
+			b[i] = make([]byte, keyLen)
+			b[i][i] = 1
+		}
+		rand.Shuffle(len(b), b.Swap)
+		return b
+	}
+	forceRadix(func() {
+		n := 0
+		data := keys()
+		ByBytes(lessCounter{data, &n})
+		if !BytesAreSorted(data) {
+			t.Errorf("ByBytes didn't sort")
+		}
+		if n == 0 {
+			t.Errorf("ByBytes didn't fall back to comparisons past %d levels", keyLen)
+		}
+
+		n = 0
+		data = keys()
+		ByBytesFixed(lessCounter{data, &n}, keyLen)
+		if !BytesAreSorted(data) {
+			t.Errorf("ByBytesFixed didn't sort")
+		}
+		if n != 0 {
+			t.Errorf("ByBytesFixed made %d comparisons", n)
+		}
+	})
+}
+
+// fixedKeys adds KeyLen to a lessCounter.
+type fixedKeys struct {
+	lessCounter
+	keyLen int
+}
+
+func (f fixedKeys) KeyLen() int { return f.keyLen }
+
+func TestByFixedBytes(t *testing.T) {
+	defer func(old bool) { Verify = old }(Verify)
+	Verify = false // it calls Less
+	const keyLen = 48
+	forceRadix(func() {
+		for _, n := range []int{0, 1, 100, 10000} {
+			// distinct keys that differ only in the last few bytes, so
+			// radix sorting has to go all the way down
+			data := make(BytesSlice, n)
+			for i, v := range rand.Perm(n) {
+				data[i] = make([]byte, keyLen)
+				binary.BigEndian.PutUint32(data[i][keyLen-4:], uint32(v)*7919)
+			}
+			want := append(BytesSlice(nil), data...)
+			ByBytes(want)
+
+			less := 0
+			ByFixedBytes(fixedKeys{lessCounter{data, &less}, keyLen})
+			for i := range data {
+				if string(data[i]) != string(want[i]) {
+					t.Fatalf("n=%d: ByFixedBytes and ByBytes disagree at %d", n, i)
+				}
+			}
+			if less != 0 {
+				t.Errorf("n=%d: ByFixedBytes made %d comparisons of %d-byte keys", n, less, keyLen)
+			}
+		}
+	})
+}
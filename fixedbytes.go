@@ -0,0 +1,90 @@
+// Copyright 2015 Randall Farmer. All rights reserved.
+
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+package sorts
+
+import (
+	"bytes"
+	"sort"
+)
+
+// ByFixedBytes sorts data by a []byte key, like ByBytes, but knowing every
+// key is data.KeyLen() bytes long.  It radix sorts all the way to the end
+// of the keys, and skips ByBytes's handling of keys that run out early.
+// Less is only used to order items with equal keys.  A key shorter than
+// KeyLen makes it panic.
+func ByFixedBytes(data FixedBytesInterface) {
+	l := data.Len()
+	if l < qSortCutoff {
+		qSort(data, 0, l)
+		return
+	}
+	if !bytesPresorted(data, 0, l) {
+		parallelSort(data, fixedBytesSorter(data.KeyLen()), task{offs: 0, pos: 0, end: l})
+	}
+
+	// check results!
+	checkBytes(data, 0, l)
+}
+
+// fixedBytesSorter returns a sortFunc like radixSortBytes for keys of
+// keyLen bytes.
+func fixedBytesSorter(keyLen int) sortFunc {
+	return func(dataI sort.Interface, t task, sortRange func(task)) {
+		data := dataI.(BytesInterface)
+		offset, a, b := t.offs, t.pos, t.end
+		if offset >= keyLen {
+			qSortEqualKeyRange(data, a, b)
+			return
+		}
+		if b-a < t.opts.qSortCutoff() {
+			qSort(data, a, b)
+			return
+		}
+
+		// count bucket sizes, and find how long a prefix all keys share
+		var bucketStarts, bucketEnds [256]int
+		first := data.Key(a)[offset:keyLen]
+		common := len(first)
+		for i := a; i < b; i++ {
+			k := data.Key(i)[offset:keyLen]
+			bucketStarts[k[0]]++
+			if common > 0 && !bytes.Equal(k[:common], first[:common]) {
+				common = commonPrefixBytes(first[:common], k)
+			}
+		}
+		if common > 0 {
+			// everything's in one bucket; skip the shared prefix
+			sortRange(task{offs: offset + common, pos: a, end: b, opts: t.opts})
+			return
+		}
+
+		pos := a
+		for i, c := range bucketStarts {
+			bucketStarts[i] = pos
+			pos += c
+			bucketEnds[i] = pos
+		}
+
+		i := a
+		for curBucket, bucketEnd := range bucketEnds {
+			start := i
+			i = bucketStarts[curBucket]
+			for i < bucketEnd {
+				destBucket := data.Key(i)[offset]
+				if destBucket == byte(curBucket) {
+					i++
+					bucketStarts[destBucket]++
+					continue
+				}
+				data.Swap(i, bucketStarts[destBucket])
+				bucketStarts[destBucket]++
+			}
+			if i > start+1 {
+				sortRange(task{offs: offset + 1, pos: start, end: i, opts: t.opts})
+			}
+		}
+	}
+}
@@ -0,0 +1,109 @@
+// Copyright 2015 Randall Farmer. All rights reserved.
+
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+package sorts
+
+import (
+	"cmp"
+
+	"github.com/twotwotwo/sorts/internal/floatkey"
+)
+
+// Slice sorts s in increasing order, radix sorting slices of the built-in
+// integer, float, and string types.  Floats are in sortutil.Float64Key's
+// order: NaNs with the sign bit clear, like math.NaN(), go last, and those
+// with it set go first.  Slices of other types, including named types like
+// time.Duration, are quicksorted, with all NaNs last.
+func Slice[T cmp.Ordered](s []T) {
+	switch a := any(s).(type) {
+	case []int:
+		ByInt64(intSlice[int](a))
+	case []int8:
+		ByInt64(intSlice[int8](a))
+	case []int16:
+		ByInt64(intSlice[int16](a))
+	case []int32:
+		ByInt64(intSlice[int32](a))
+	case []int64:
+		ByInt64(intSlice[int64](a))
+	case []uint:
+		ByUint64(uintSlice[uint](a))
+	case []uint8:
+		ByUint64(uintSlice[uint8](a))
+	case []uint16:
+		ByUint64(uintSlice[uint16](a))
+	case []uint32:
+		ByUint64(uintSlice[uint32](a))
+	case []uint64:
+		ByUint64(uintSlice[uint64](a))
+	case []uintptr:
+		ByUint64(uintSlice[uintptr](a))
+	case []float32:
+		ByUint64(floatSlice[float32](a))
+	case []float64:
+		ByUint64(floatSlice[float64](a))
+	case []string:
+		ByString(stringSlice[string](a))
+	default:
+		Quicksort(orderedSlice[T](s))
+	}
+}
+
+// SortFunc sorts s by the uint64 key that key returns for each item.  key
+// may be called several times per item, so it should be cheap.
+func SortFunc[T any](s []T, key func(T) uint64) {
+	ByUint64(funcSlice[T]{s, key})
+}
+
+type intSlice[T ~int | ~int8 | ~int16 | ~int32 | ~int64] []T
+
+func (p intSlice[T]) Len() int           { return len(p) }
+func (p intSlice[T]) Less(i, j int) bool { return p[i] < p[j] }
+func (p intSlice[T]) Swap(i, j int)      { p[i], p[j] = p[j], p[i] }
+func (p intSlice[T]) Key(i int) int64    { return int64(p[i]) }
+
+type uintSlice[T ~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr] []T
+
+func (p uintSlice[T]) Len() int           { return len(p) }
+func (p uintSlice[T]) Less(i, j int) bool { return p[i] < p[j] }
+func (p uintSlice[T]) Swap(i, j int)      { p[i], p[j] = p[j], p[i] }
+func (p uintSlice[T]) Key(i int) uint64   { return uint64(p[i]) }
+
+type floatSlice[T ~float32 | ~float64] []T
+
+func (p floatSlice[T]) Len() int           { return len(p) }
+func (p floatSlice[T]) Less(i, j int) bool { return p.Key(i) < p.Key(j) }
+func (p floatSlice[T]) Swap(i, j int)      { p[i], p[j] = p[j], p[i] }
+
+// Key converts to float64, which preserves order (and NaN-ness) for
+// float32s.
+func (p floatSlice[T]) Key(i int) uint64 { return floatkey.Float64(float64(p[i])) }
+
+type stringSlice[T ~string] []T
+
+func (p stringSlice[T]) Len() int           { return len(p) }
+func (p stringSlice[T]) Less(i, j int) bool { return p[i] < p[j] }
+func (p stringSlice[T]) Swap(i, j int)      { p[i], p[j] = p[j], p[i] }
+func (p stringSlice[T]) Key(i int) string   { return string(p[i]) }
+
+// orderedSlice is for types Slice can't radix sort.  Its Less puts NaNs
+// last, whatever their sign bit.
+type orderedSlice[T cmp.Ordered] []T
+
+func (p orderedSlice[T]) Len() int { return len(p) }
+func (p orderedSlice[T]) Less(i, j int) bool {
+	return p[i] < p[j] || (p[j] != p[j] && p[i] == p[i])
+}
+func (p orderedSlice[T]) Swap(i, j int) { p[i], p[j] = p[j], p[i] }
+
+type funcSlice[T any] struct {
+	s   []T
+	key func(T) uint64
+}
+
+func (f funcSlice[T]) Len() int           { return len(f.s) }
+func (f funcSlice[T]) Less(i, j int) bool { return f.key(f.s[i]) < f.key(f.s[j]) }
+func (f funcSlice[T]) Swap(i, j int)      { f.s[i], f.s[j] = f.s[j], f.s[i] }
+func (f funcSlice[T]) Key(i int) uint64   { return f.key(f.s[i]) }
@@ -0,0 +1,100 @@
+// Copyright 2015 Randall Farmer. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sorts_test
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+	"strconv"
+	"testing"
+
+	. "github.com/twotwotwo/sorts"
+)
+
+func randomSlice[T any](n int, gen func() T) []T {
+	s := make([]T, n)
+	for i := range s {
+		s[i] = gen()
+	}
+	return s
+}
+
+// isSortedOrdered is like sort.IsSorted with NaNs last.
+func isSortedOrdered[T ~int8 | ~int | ~uint16 | ~uint64 | ~float32 | ~float64 | ~string](s []T) bool {
+	for i := 1; i < len(s); i++ {
+		if s[i] < s[i-1] || (s[i-1] != s[i-1] && s[i] == s[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+type myInt int
+
+func TestSlice(t *testing.T) {
+	n := 1000
+	forceRadix(func() {
+		int8s := randomSlice(n, func() int8 { return int8(rand.Intn(256) - 128) })
+		Slice(int8s)
+		if !isSortedOrdered(int8s) {
+			t.Errorf("Slice didn't sort int8s")
+		}
+		ints := randomSlice(n, func() int { return rand.Int() - rand.Int() })
+		Slice(ints)
+		if !isSortedOrdered(ints) {
+			t.Errorf("Slice didn't sort ints")
+		}
+		uint16s := randomSlice(n, func() uint16 { return uint16(rand.Intn(1 << 16)) })
+		Slice(uint16s)
+		if !isSortedOrdered(uint16s) {
+			t.Errorf("Slice didn't sort uint16s")
+		}
+		uint64s := randomSlice(n, func() uint64 { return rand.Uint64() })
+		Slice(uint64s)
+		if !isSortedOrdered(uint64s) {
+			t.Errorf("Slice didn't sort uint64s")
+		}
+		float32s := randomSlice(n, func() float32 { return float32(rand.NormFloat64()) })
+		float32s[0] = float32(math.NaN())
+		Slice(float32s)
+		if !isSortedOrdered(float32s) || !math.IsNaN(float64(float32s[n-1])) {
+			t.Errorf("Slice didn't sort float32s")
+		}
+		float64s := randomSlice(n, func() float64 { return rand.NormFloat64() })
+		float64s[0], float64s[1] = math.NaN(), math.Inf(-1)
+		Slice(float64s)
+		if !isSortedOrdered(float64s) || !math.IsNaN(float64s[n-1]) {
+			t.Errorf("Slice didn't sort float64s")
+		}
+		strings := randomSlice(n, func() string { return strconv.Itoa(rand.Int()) })
+		Slice(strings)
+		if !isSortedOrdered(strings) {
+			t.Errorf("Slice didn't sort strings")
+		}
+		myInts := randomSlice(n, func() myInt { return myInt(rand.Int()) })
+		Slice(myInts)
+		if !isSortedOrdered(myInts) {
+			t.Errorf("Slice didn't sort a named type")
+		}
+	})
+}
+
+func TestSortFunc(t *testing.T) {
+	type record struct {
+		ID   uint64
+		Name string
+	}
+	records := randomSlice(1000, func() record {
+		id := rand.Uint64()
+		return record{id, strconv.FormatUint(id, 10)}
+	})
+	forceRadix(func() {
+		SortFunc(records, func(r record) uint64 { return r.ID })
+	})
+	if !sort.SliceIsSorted(records, func(i, j int) bool { return records[i].ID < records[j].ID }) {
+		t.Errorf("SortFunc didn't sort")
+	}
+}
@@ -0,0 +1,37 @@
+// Copyright 2015 Randall Farmer. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sorts_test
+
+import (
+	"math/rand"
+	"testing"
+
+	. "github.com/twotwotwo/sorts/sortutil"
+)
+
+// benchGuess sorts size ints spread uniformly over [0, size), with
+// outliers of them replaced by -1.
+func benchGuess(b *testing.B, size, outliers int) {
+	b.StopTimer()
+	data := make([]int, size)
+	for i := 0; i < b.N; i++ {
+		for i := range data {
+			data[i] = rand.Intn(size)
+		}
+		for i := 0; i < outliers; i++ {
+			data[rand.Intn(size)] = -1
+		}
+		b.StartTimer()
+		Ints(data)
+		b.StopTimer()
+	}
+}
+
+func BenchmarkGuessUniform1e3(b *testing.B)     { benchGuess(b, 1e3, 0) }
+func BenchmarkGuessUniform1e4(b *testing.B)     { benchGuess(b, 1e4, 0) }
+func BenchmarkGuessLoneOutlier1e3(b *testing.B) { benchGuess(b, 1e3, 1) }
+func BenchmarkGuessLoneOutlier1e4(b *testing.B) { benchGuess(b, 1e4, 1) }
+func BenchmarkGuessOutliers1e3(b *testing.B)    { benchGuess(b, 1e3, 1e3/200) }
+func BenchmarkGuessOutliers1e4(b *testing.B)    { benchGuess(b, 1e4, 1e4/200) }
@@ -0,0 +1,83 @@
+// Copyright 2015 Randall Farmer. All rights reserved.
+
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+package index
+
+// A FrozenIndex is a read-only handle on an Index, safe to share among
+// goroutines that search it concurrently.  Its methods only read Keys,
+// Summary, Perm, and Data, and take no locks; it's on you to not mutate
+// Data (or swap or re-sort the Index it came from) while it's in use.
+type FrozenIndex struct {
+	idx Index
+}
+
+// Freeze returns a FrozenIndex for idx.  Call Summarize first if you want
+// the faster lookups it gives; the FrozenIndex copies idx's fields, so
+// later changes to them (though not to the contents of Keys or Data)
+// don't reach it.
+func (idx *Index) Freeze() *FrozenIndex {
+	return &FrozenIndex{idx: *idx}
+}
+
+// Len returns the number of items indexed.
+func (f *FrozenIndex) Len() int { return len(f.idx.Keys) }
+
+// Position returns where in Data the item at position i is; see
+// Index.Position.
+func (f *FrozenIndex) Position(i int) int { return f.idx.Position(i) }
+
+// Key returns the uint64 key at position i; see Index.Key.
+func (f *FrozenIndex) Key(i int) uint64 { return f.idx.Key(i) }
+
+// Descending reports whether the Index was in descending order.
+func (f *FrozenIndex) Descending() bool { return f.idx.Descending }
+
+// FindUint64 is Index.FindUint64.
+func (f *FrozenIndex) FindUint64(key uint64) int { return f.idx.FindUint64(key) }
+
+// FindUint64Range is Index.FindUint64Range.
+func (f *FrozenIndex) FindUint64Range(key uint64) (a, b int) { return f.idx.FindUint64Range(key) }
+
+// FindString is Index.FindString.
+func (f *FrozenIndex) FindString(key string) int { return f.idx.FindString(key) }
+
+// FindStringApprox is Index.FindStringApprox.
+func (f *FrozenIndex) FindStringApprox(key string) int { return f.idx.FindStringApprox(key) }
+
+// FindStringRange is Index.FindStringRange.
+func (f *FrozenIndex) FindStringRange(key string) (int, int) { return f.idx.FindStringRange(key) }
+
+// FindBytes is Index.FindBytes.
+func (f *FrozenIndex) FindBytes(key []byte) int { return f.idx.FindBytes(key) }
+
+// FindBytesApprox is Index.FindBytesApprox.
+func (f *FrozenIndex) FindBytesApprox(key []byte) int { return f.idx.FindBytesApprox(key) }
+
+// FindBytesRange is Index.FindBytesRange.
+func (f *FrozenIndex) FindBytesRange(key []byte) (int, int) { return f.idx.FindBytesRange(key) }
+
+// FindPrefix is Index.FindPrefix.
+func (f *FrozenIndex) FindPrefix(prefix string) (int, int) { return f.idx.FindPrefix(prefix) }
+
+// FindFloat64 is Index.FindFloat64.
+func (f *FrozenIndex) FindFloat64(x float64) int { return f.idx.FindFloat64(x) }
+
+// FindFloat64Range is Index.FindFloat64Range.
+func (f *FrozenIndex) FindFloat64Range(x float64) (a, b int) { return f.idx.FindFloat64Range(x) }
+
+// FindFloat32 is Index.FindFloat32.
+func (f *FrozenIndex) FindFloat32(x float32) int { return f.idx.FindFloat32(x) }
+
+// FindFloat32Range is Index.FindFloat32Range.
+func (f *FrozenIndex) FindFloat32Range(x float32) (a, b int) { return f.idx.FindFloat32Range(x) }
+
+// CountUint64 is Index.CountUint64.
+func (f *FrozenIndex) CountUint64(key uint64) int { return f.idx.CountUint64(key) }
+
+// CountString is Index.CountString.
+func (f *FrozenIndex) CountString(key string) int { return f.idx.CountString(key) }
+
+// CountBytes is Index.CountBytes.
+func (f *FrozenIndex) CountBytes(key []byte) int { return f.idx.CountBytes(key) }
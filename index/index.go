@@ -13,74 +13,181 @@ import (
 	"strings"
 
 	"github.com/twotwotwo/sorts"
+	"github.com/twotwotwo/sorts/sortutil"
 )
 
+// An Index holds a uint64 key for each item in Data, in sorted order, to
+// speed sorting and searching Data.  It implements sort.Interface, ordering
+// by key, then Data.Less, so besides sorts.ByUint64, sort.Sort and
+// sort.Stable sort it (and Data, or Perm if set) into that same order;
+// sort.Stable also keeps items equal by both in their original order.
+// Sorting makes the Summary stale, so call Summarize again afterwards.
 type Index struct {
 	Keys    []uint64
 	Summary []uint64 // implicit B-tree, if Summarize() was called
 	Data    sort.Interface
+	// Perm, if set, maps positions in Keys to positions in Data, which
+	// then isn't kept in key order; see BuildIndex.
+	Perm []int
+	// Descending reverses the Index's order: Keys run from highest to
+	// lowest, and items with equal keys are in reverse Data.Less order.
+	// Find methods search in that order, so "the first item >= key"
+	// becomes the first item <= key.  See SortWithIndexDesc.
+	Descending bool
+
+	summaryBits int // Summary's levelBits, if not defaultLevelBits
 }
 
 // Len returns the length of the data underlying an Index
-func (idx *Index) Len() int { return idx.Data.Len() }
+func (idx *Index) Len() int {
+	if idx.Perm != nil {
+		return len(idx.Perm)
+	}
+	return idx.Data.Len()
+}
 
 // Less compares Index elements by their Keys, falling back to Data.Less for
-// equal-keyed items.
+// equal-keyed items, with both reversed if the Index is Descending.
 func (idx *Index) Less(i, j int) bool {
-	return idx.Keys[i] < idx.Keys[j] || (idx.Keys[i] == idx.Keys[j] && idx.Data.Less(i, j))
+	if idx.Descending {
+		i, j = j, i
+	}
+	return idx.Keys[i] < idx.Keys[j] || (idx.Keys[i] == idx.Keys[j] && idx.Data.Less(idx.Position(i), idx.Position(j)))
 }
 
 // Swap swaps both the Keys and the inderlying data items at indices i and
-// j.
+// j, or the Keys and Perm entries if there's a Perm.
 func (idx *Index) Swap(i, j int) {
 	idx.Keys[i], idx.Keys[j] = idx.Keys[j], idx.Keys[i]
+	if idx.Perm != nil {
+		idx.Perm[i], idx.Perm[j] = idx.Perm[j], idx.Perm[i]
+		return
+	}
 	idx.Data.Swap(i, j)
 }
 
-// Key returns the uint64 key at index i.
-func (idx *Index) Key(i int) uint64 { return idx.Keys[i] }
+// Position returns where in Data the item at position i in the Index is:
+// Perm[i] if there's a Perm, or else just i.
+func (idx *Index) Position(i int) int {
+	if idx.Perm != nil {
+		return idx.Perm[i]
+	}
+	return i
+}
+
+// Key returns the uint64 key to radix sort position i by: Keys[i], or
+// ^Keys[i] if the Index is Descending.
+func (idx *Index) Key(i int) uint64 {
+	if idx.Descending {
+		return ^idx.Keys[i]
+	}
+	return idx.Keys[i]
+}
+
+// before reports whether key k sorts before key in idx's order.
+func (idx *Index) before(k, key uint64) bool {
+	if idx.Descending {
+		return k > key
+	}
+	return k < key
+}
+
+// dir is 1, or -1 if idx is Descending; multiplying a comparison result by
+// it turns "sorts before" into "sorts before in idx's order".
+func (idx *Index) dir() int {
+	if idx.Descending {
+		return -1
+	}
+	return 1
+}
 
-// levelBits and pageSize control the fan-out of Summary, the implicit
-// B-tree.  6 won a very informal bake-off.  (Would have guessed 3, matching
-// 8-word amd64 cache lines.) More would work better if this were ever on
-// block storage, e.g.  levelBits of 9 corresponds to a page size of 4KiB.
-const levelBits = 6
-const pageSize = 1 << levelBits
+// defaultLevelBits is the log2 of the fan-out of Summary, the implicit
+// B-tree, that Summarize uses.  6 won a very informal bake-off.  (Would have
+// guessed 3, matching 8-word amd64 cache lines.) More would work better if
+// this were ever on block storage, e.g.  levelBits of 9 corresponds to a
+// page size of 4KiB.
+const defaultLevelBits = 6
+
+// maxLevelBits caps the levelBits SummarizeLevelBits takes.
+const maxLevelBits = 16
 
 // Summarize makes an implicit B-tree to speed lookups, using a few percent
 // overhead on top of what's already used for Indices.
-func (idx *Index) Summarize() {
-	l := idx.Len()
-	sl := l>>levelBits + l>>levelBits*2 + l>>levelBits*3 + l>>((levelBits*4)-1)
+func (idx *Index) Summarize() { idx.SummarizeLevelBits(defaultLevelBits) }
+
+// SummarizeLevelBits is Summarize with a fan-out of 1<<levelBits keys per
+// page of the B-tree instead of Summarize's 1<<6, for tuning lookups on
+// big Indexes.  levelBits must be from 1 to 16.
+func (idx *Index) SummarizeLevelBits(levelBits int) {
+	if levelBits < 1 || levelBits > maxLevelBits {
+		panic("index: SummarizeLevelBits needs levelBits from 1 to 16")
+	}
+	pageSize := 1 << uint(levelBits)
+	l := len(idx.Keys)
+	levels := summaryLevels(l, levelBits)
+	sl := 0
+	for level := 1; level <= levels; level++ {
+		sl += summaryLevelLen(l, level, levelBits)
+	}
 	summary := make([]uint64, 0, sl)
 	summarizing := idx.Keys
-	levelNum := 1
-	for len(summarizing) > pageSize {
+	for level := 1; level <= levels; level++ {
 		start := len(summary)
 		for i := 0; i < len(summarizing); i += pageSize {
 			summary = append(summary, summarizing[i])
 		}
 		summarizing = summary[start:]
-		levelNum++
 	}
 	idx.Summary = summary
+	idx.summaryBits = levelBits
+}
+
+// levelBits is the levelBits idx's Summary was made with.
+func (idx *Index) levelBits() int {
+	if idx.summaryBits == 0 {
+		return defaultLevelBits
+	}
+	return idx.summaryBits
+}
+
+// summaryLevels is how many levels the Summary of l keys has: one for each
+// power of the page size, 1<<levelBits, <= l.
+func summaryLevels(l, levelBits int) int {
+	levels := 0
+	for l >= 1<<uint(levelBits) {
+		levels++
+		l >>= uint(levelBits)
+	}
+	return levels
+}
+
+// summaryLevelLen is how many entries level (counting from 1) of the
+// Summary of l keys has: one per page of the level below, rounding up.
+func summaryLevelLen(l, level, levelBits int) int {
+	bits := uint(levelBits * level)
+	n := l >> bits
+	if l > n<<bits {
+		// an entry for the remainder
+		n++
+	}
+	return n
 }
 
-// FindUint64 finds the position of the first item >= key in Keys, returning
-// one after the end if there is none.  When different values map to the same key,
-// you might want to sort.Search within the returned range to narrow your result
-// down to the desired values.
+// FindUint64 finds the position of the first item >= key in Keys (<= key if
+// the Index is Descending), returning one after the end if there is none.
+// When different values map to the same key, you might want to sort.Search
+// within the returned range to narrow your result down to the desired values.
 func (idx *Index) FindUint64(key uint64) int {
 	if idx.Summary != nil {
 		return idx.findUint64Summary(key)
 	}
-	return sort.Search(idx.Len(), func(i int) bool { return idx.Keys[i] >= key })
+	return sort.Search(len(idx.Keys), func(i int) bool { return !idx.before(idx.Keys[i], key) })
 }
 
 // Compares string a to []byte b, returning -1 if a<b, 0 if a==b, and 1 if a>b.
 func CompareStringToBytes(a string, b []byte) int {
 	for i := range b {
-		if i > len(a) {
+		if i >= len(a) {
 			return -1
 		}
 		if b[i] > a[i] {
@@ -109,17 +216,28 @@ func (idx *Index) FindString(key string) int {
 	switch data := idx.Data.(type) {
 	case sorts.StringInterface:
 		return a + sort.Search(b-a, func(i int) bool {
-			return strings.Compare(key, data.Key(a+i)) >= 0
+			return idx.dir()*strings.Compare(key, data.Key(idx.Position(a+i))) <= 0
 		})
 	case sorts.BytesInterface:
 		return a + sort.Search(b-a, func(i int) bool {
-			return CompareStringToBytes(key, data.Key(a+i)) >= 0
+			return idx.dir()*CompareStringToBytes(key, data.Key(idx.Position(a+i))) <= 0
 		})
 	default:
 		panic("to use FindStringKey, Data.Key(i) must return string or []byte")
 	}
 }
 
+// FindStringApprox finds the first item whose uint64 key, made from the
+// first 8 bytes of the string, is >= StringKey(key), without looking at
+// Data, so it works on an Index whose Data is gone, like one loaded by
+// ReadIndex.  For keys of 8 bytes or less it's FindString's answer; for
+// longer ones, the result is the start of the run of items that share
+// key's first 8 bytes, which FindString would narrow down.
+func (idx *Index) FindStringApprox(key string) int { return idx.FindUint64(StringKey(key)) }
+
+// FindBytesApprox is FindStringApprox for []byte keys.
+func (idx *Index) FindBytesApprox(key []byte) int { return idx.FindUint64(BytesKey(key)) }
+
 // FindBytes finds the first item >= key, returning one after the end if there
 // is none. The collection type must implement Key(i) returning string or []byte.
 func (idx *Index) FindBytes(key []byte) int {
@@ -128,11 +246,11 @@ func (idx *Index) FindBytes(key []byte) int {
 	switch data := idx.Data.(type) {
 	case sorts.StringInterface:
 		return a + sort.Search(b-a, func(i int) bool {
-			return CompareBytesToString(key, data.Key(a+i)) >= 0
+			return idx.dir()*CompareBytesToString(key, data.Key(idx.Position(a+i))) <= 0
 		})
 	case sorts.BytesInterface:
 		offset := sort.Search(b-a, func(i int) bool {
-			return bytes.Compare(key, data.Key(a+i)) >= 0
+			return idx.dir()*bytes.Compare(key, data.Key(idx.Position(a+i))) <= 0
 		})
 		return a + offset
 	default:
@@ -150,14 +268,39 @@ func (idx *Index) FindUint64Range(key uint64) (a, b int) {
 		// key not found, needn't search again
 		return a, a
 	}
-	if key == ^uint64(0) { // would overflow
-		b = len(idx.Keys)
-	} else {
+	switch {
+	case !idx.Descending && key != ^uint64(0):
 		b = idx.FindUint64(key + 1)
+	case idx.Descending && key != 0:
+		b = idx.FindUint64(key - 1)
+	default: // would overflow
+		b = len(idx.Keys)
 	}
 	return
 }
 
+// FindFloat64 finds the first item >= x in an Index keyed by
+// sortutil.Float64Key, like one over a sortutil.Float64Slice or from
+// SortFloat64WithIndex, returning one after the end if there is none.
+func (idx *Index) FindFloat64(x float64) int { return idx.FindUint64(sortutil.Float64Key(x)) }
+
+// FindFloat64Range finds the range [a,b) of items equal to x in an Index
+// keyed by sortutil.Float64Key, as FindUint64Range does.
+func (idx *Index) FindFloat64Range(x float64) (a, b int) {
+	return idx.FindUint64Range(sortutil.Float64Key(x))
+}
+
+// FindFloat32 finds the first item >= x in an Index keyed by
+// sortutil.Float32Key, like one over a sortutil.Float32Slice, returning one
+// after the end if there is none.
+func (idx *Index) FindFloat32(x float32) int { return idx.FindUint64(sortutil.Float32Key(x)) }
+
+// FindFloat32Range finds the range [a,b) of items equal to x in an Index
+// keyed by sortutil.Float32Key, as FindUint64Range does.
+func (idx *Index) FindFloat32Range(x float32) (a, b int) {
+	return idx.FindUint64Range(sortutil.Float32Key(x))
+}
+
 // FindStringRange(key) finds the range (a,b] such that Key() returns key for all items in idx.Data[a:b].
 // It can return an empty range if the item isn't found; in that case, a and b are both where the item would be inserted (and can be one past the end).
 // Data must implement Key(i) returning string or []byte.
@@ -168,18 +311,18 @@ func (idx *Index) FindStringRange(key string) (int, int) {
 	switch data := idx.Data.(type) {
 	case sorts.StringInterface:
 		aa := a + sort.Search(b-a, func(i int) bool {
-			return strings.Compare(key, data.Key(a+i)) >= 0
+			return idx.dir()*strings.Compare(key, data.Key(idx.Position(a+i))) <= 0
 		})
 		bb := aa + sort.Search(b-aa, func(i int) bool {
-			return strings.Compare(key, data.Key(aa+i)) > 0
+			return idx.dir()*strings.Compare(key, data.Key(idx.Position(aa+i))) < 0
 		})
 		return aa, bb
 	case sorts.BytesInterface:
 		aa := a + sort.Search(b-a, func(i int) bool {
-			return CompareStringToBytes(key, data.Key(a+i)) >= 0
+			return idx.dir()*CompareStringToBytes(key, data.Key(idx.Position(a+i))) <= 0
 		})
 		bb := aa + sort.Search(b-aa, func(i int) bool {
-			return CompareStringToBytes(key, data.Key(aa+i)) > 0
+			return idx.dir()*CompareStringToBytes(key, data.Key(idx.Position(aa+i))) < 0
 		})
 		return aa, bb
 	default:
@@ -187,6 +330,81 @@ func (idx *Index) FindStringRange(key string) (int, int) {
 	}
 }
 
+// CountUint64 returns how many items have key key, or 0 if there are none.
+func (idx *Index) CountUint64(key uint64) int {
+	a, b := idx.FindUint64Range(key)
+	return b - a
+}
+
+// CountString returns how many items have key key, or 0 if there are none.
+// Data must implement Key(i) returning string or []byte.
+func (idx *Index) CountString(key string) int {
+	a, b := idx.FindStringRange(key)
+	return b - a
+}
+
+// CountBytes returns how many items have key key, or 0 if there are none.
+// Data must implement Key(i) returning string or []byte.
+func (idx *Index) CountBytes(key []byte) int {
+	a, b := idx.FindBytesRange(key)
+	return b - a
+}
+
+// FindPrefix finds the range [a,b) of items whose keys start with prefix.
+// If there are none, a and b are both where prefix would be inserted.  Data
+// must implement Key(i) returning string or []byte.
+func (idx *Index) FindPrefix(prefix string) (int, int) {
+	// narrow down using the uint64 keys, which hold up to 8 bytes
+	a, b := 0, len(idx.Keys)
+	if len(prefix) >= 8 {
+		a, b = idx.FindUint64Range(StringKey(prefix))
+	} else if len(prefix) > 0 {
+		lo := StringKey(prefix)
+		hi := lo | (1<<uint(64-8*len(prefix)) - 1)
+		if idx.Descending {
+			lo, hi = hi, lo
+		}
+		a = idx.FindUint64(lo)
+		if hi != ^uint64(0) && !idx.Descending {
+			b = idx.FindUint64(hi + 1)
+		} else if hi != 0 && idx.Descending {
+			b = idx.FindUint64(hi - 1)
+		}
+	}
+
+	// keys sorting before all with the prefix come first, then ones with
+	// it, then ones after
+	switch data := idx.Data.(type) {
+	case sorts.StringInterface:
+		aa := a + sort.Search(b-a, func(i int) bool {
+			k := data.Key(idx.Position(a + i))
+			if idx.Descending {
+				return k < prefix || strings.HasPrefix(k, prefix)
+			}
+			return k >= prefix
+		})
+		bb := aa + sort.Search(b-aa, func(i int) bool {
+			return !strings.HasPrefix(data.Key(idx.Position(aa+i)), prefix)
+		})
+		return aa, bb
+	case sorts.BytesInterface:
+		aa := a + sort.Search(b-a, func(i int) bool {
+			k := string(data.Key(idx.Position(a + i)))
+			if idx.Descending {
+				return k < prefix || strings.HasPrefix(k, prefix)
+			}
+			return k >= prefix
+		})
+		bb := aa + sort.Search(b-aa, func(i int) bool {
+			k := data.Key(idx.Position(aa + i))
+			return len(k) < len(prefix) || string(k[:len(prefix)]) != prefix
+		})
+		return aa, bb
+	default:
+		panic("to use FindPrefix, Data.Key(i) must return string or []byte")
+	}
+}
+
 // FindBytesRange(key) finds the range (a,b] such that Key() returns key for all items in idx.Data[a:b].
 // It can return an empty range if the item isn't found; in that case, a is where the item would be inserted (and can be one past the end).
 // Data must implement Key(i) returning string or []byte.
@@ -197,18 +415,18 @@ func (idx *Index) FindBytesRange(key []byte) (int, int) {
 	switch data := idx.Data.(type) {
 	case sorts.StringInterface:
 		aa := a + sort.Search(b-a, func(i int) bool {
-			return CompareBytesToString(key, data.Key(a+i)) >= 0
+			return idx.dir()*CompareBytesToString(key, data.Key(idx.Position(a+i))) <= 0
 		})
 		bb := aa + sort.Search(b-aa, func(i int) bool {
-			return CompareBytesToString(key, data.Key(aa+i)) > 0
+			return idx.dir()*CompareBytesToString(key, data.Key(idx.Position(aa+i))) < 0
 		})
 		return aa, bb
 	case sorts.BytesInterface:
 		aa := a + sort.Search(b-a, func(i int) bool {
-			return bytes.Compare(key, data.Key(a+i)) >= 0
+			return idx.dir()*bytes.Compare(key, data.Key(idx.Position(a+i))) <= 0
 		})
 		bb := aa + sort.Search(b-aa, func(i int) bool {
-			return bytes.Compare(key, data.Key(aa+i)) > 0
+			return idx.dir()*bytes.Compare(key, data.Key(idx.Position(aa+i))) < 0
 		})
 		return aa, bb
 	default:
@@ -219,27 +437,16 @@ func (idx *Index) FindBytesRange(key []byte) (int, int) {
 func (idx *Index) findUint64Summary(key uint64) int {
 	summary := idx.Summary
 	keys := idx.Keys
-
-	// count how many layers to expect in the "btree"
-	levels, l := 0, len(keys)
-	for l > 0 {
-		levels++
-		l >>= levelBits
-	}
-	levels-- // went one too far
+	levelBits := idx.levelBits()
+	pageSize := 1 << uint(levelBits)
 
 	// keep following largest-strictly-less down the chain
-	levelNum := levels
+	levelNum := summaryLevels(len(keys), levelBits)
 	levelEnd := len(summary)
 	offset := 0
 	for levelNum > 0 {
 		// extract the "level"
-		thisLevelBits := uint(levelBits * levelNum)
-		levelLen := len(keys) >> thisLevelBits
-		if len(keys) > levelLen<<thisLevelBits {
-			// an entry for the remainder
-			levelLen++
-		}
+		levelLen := summaryLevelLen(len(keys), levelNum, levelBits)
 		level := summary[levelEnd-levelLen : levelEnd]
 
 		// extract the page at the given offset
@@ -252,7 +459,7 @@ func (idx *Index) findUint64Summary(key uint64) int {
 		// scan page for an entry >= key
 		// binsearch would be fewer operations but less predictable ones
 		i := 0
-		for i < len(page) && page[i] < key {
+		for i < len(page) && idx.before(page[i], key) {
 			i++
 		}
 		if i > 0 {
@@ -263,7 +470,7 @@ func (idx *Index) findUint64Summary(key uint64) int {
 		// use that to walk down the tree
 		// in particular, get next offset and level location
 		offset += i
-		offset <<= levelBits
+		offset <<= uint(levelBits)
 		levelEnd -= levelLen
 		levelNum--
 	}
@@ -275,7 +482,7 @@ func (idx *Index) findUint64Summary(key uint64) int {
 	}
 	page := keys[offset:pageEnd]
 	i := 0
-	for i < len(page) && page[i] < key {
+	for i < len(page) && idx.before(page[i], key) {
 		i++
 	}
 	return offset + i
@@ -299,13 +506,42 @@ func BytesKey(key []byte) uint64 {
 	return k
 }
 
+// Int64Key generates a uint64 key from an int64, flipping the sign bit so
+// negative numbers sort first.
+func Int64Key(key int64) uint64 { return uint64(key) ^ 1<<63 }
+
 // SortWithIndex allocates an Index with space for a uint64 key for each
 // item in data, then sorts items by their uint64 keys, using data.Less as a
 // tie-breaker for equal-keyed items.  data may implement index.KeySetter or
-// any of sorts.StringInterface, BytesInterface, or Uint64Interface.
+// any of sorts.StringInterface, BytesInterface, Uint64Interface, or
+// Int64Interface.
 func SortWithIndex(data sort.Interface) *Index {
+	idx := newIndex(data, nil)
+	sorts.ByUint64(idx)
+	return idx
+}
+
+// SortWithIndexInto is SortWithIndex, but keeps the keys in keys if it has
+// the capacity, so code sorting batch after batch can reuse one buffer
+// instead of allocating one each time.  The Index's Keys is then keys
+// resliced to data.Len(); if keys is too small, a new slice is allocated,
+// and the caller can keep that one (from the Index) for next time.
+func SortWithIndexInto(data sort.Interface, keys []uint64) *Index {
+	idx := newIndex(data, keys)
+	sorts.ByUint64(idx)
+	return idx
+}
+
+// newIndex makes an unsorted Index over data for SortWithIndex, keeping
+// the keys in buf if it's big enough.
+func newIndex(data sort.Interface, buf []uint64) *Index {
 	l := data.Len()
-	indices := make([]uint64, l)
+	var indices []uint64
+	if cap(buf) >= l {
+		indices = buf[:l]
+	} else {
+		indices = make([]uint64, l)
+	}
 	idx := &Index{
 		Keys: indices,
 		Data: data,
@@ -333,9 +569,85 @@ func SortWithIndex(data sort.Interface) *Index {
 		for i := 0; i < l; i++ {
 			indices[i] = data.Key(i)
 		}
+	case sorts.Int64Interface:
+		for i := 0; i < l; i++ {
+			indices[i] = Int64Key(data.Key(i))
+		}
 	default:
 		panic("don't know how to extract int keys for data")
 	}
+	return idx
+}
+
+// SortWithIndexDesc is SortWithIndex, but returns a Descending Index,
+// with data sorted from highest key to lowest.
+func SortWithIndexDesc(data sort.Interface) *Index {
+	idx := newIndex(data, nil)
+	idx.Descending = true
+	sorts.ByUint64(idx)
+	return idx
+}
+
+// SortWithIndexPermute is SortWithIndex for data whose Swap is expensive,
+// like big structs: it sorts the keys along with a permutation, without
+// touching data, then moves each item to its place by following the
+// permutation's cycles, so data.Swap is called the fewest times possible,
+// n minus the number of cycles.  It uses a []int of scratch space besides
+// the Index.
+func SortWithIndexPermute(data sort.Interface) *Index {
+	idx := newIndex(data, nil)
+	idx.Perm = make([]int, len(idx.Keys))
+	for i := range idx.Perm {
+		idx.Perm[i] = i
+	}
 	sorts.ByUint64(idx)
+	permute(data, idx.Perm)
+	idx.Perm = nil
 	return idx
 }
+
+// permute moves the item at perm[i] in data to i, for all i, consuming
+// perm.
+func permute(data sort.Interface, perm []int) {
+	for i, p := range perm {
+		if p == i {
+			continue
+		}
+		cur := i
+		for {
+			next := perm[cur]
+			perm[cur] = cur
+			if next == i {
+				break
+			}
+			data.Swap(cur, next)
+			cur = next
+		}
+	}
+}
+
+// BuildIndex makes an Index over data by the uint64 keys key returns,
+// without reordering data: the Index's Perm holds the position in data of
+// each sorted key, so several Indexes can share the same data.  Look up
+// the range [a,b) you want with FindUint64Range or the like, then
+// Perm[a:b] (or Position) gives the matching items' positions in data.
+// Equal-keyed items are ordered by data.Less.  key is called once per
+// item.
+func BuildIndex(data sort.Interface, key func(i int) uint64) *Index {
+	l := data.Len()
+	idx := &Index{Keys: make([]uint64, l), Data: data, Perm: make([]int, l)}
+	for i := range idx.Keys {
+		idx.Keys[i] = key(i)
+		idx.Perm[i] = i
+	}
+	sorts.ByUint64(idx)
+	return idx
+}
+
+// SortFloat64WithIndex sorts a in increasing order, as sortutil.Float64s
+// does, and returns an Index over it keyed by sortutil.Float64Key, so NaNs
+// land where Float64Less puts them.  Look values up with FindFloat64 or
+// FindFloat64Range.
+func SortFloat64WithIndex(a []float64) *Index {
+	return SortWithIndex(sortutil.Float64Slice(a))
+}
@@ -0,0 +1,652 @@
+// Copyright 2015 Randall Farmer. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package index_test
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/twotwotwo/sorts"
+	. "github.com/twotwotwo/sorts/index"
+	"github.com/twotwotwo/sorts/sortutil"
+)
+
+func TestSortWithIndexInt64(t *testing.T) {
+	data := make(sortutil.Int64Slice, 10000)
+	for i := range data {
+		data[i] = rand.Int63n(2000) - 1000
+	}
+	data[0], data[1] = -1<<63, 1<<63-1
+	idx := SortWithIndex(data)
+	if !sortutil.Int64sAreSorted(data) {
+		t.Errorf("signed data didn't sort through an index")
+	}
+	if i := idx.FindUint64(Int64Key(-1)); data[i] != -1 || data[i-1] == -1 {
+		t.Errorf("FindUint64(Int64Key(-1)) found %d", i)
+	}
+}
+
+func TestSortFloat64WithIndex(t *testing.T) {
+	data := make([]float64, 10000)
+	for i := range data {
+		data[i] = float64(rand.Intn(2000)-1000) / 4
+	}
+	data[0], data[1], data[2], data[3] = math.NaN(), math.Inf(-1), math.Inf(1), -0.25
+	idx := SortFloat64WithIndex(data)
+	if !sortutil.Float64sAreSorted(data) {
+		t.Errorf("floats didn't sort through an index")
+	}
+	if !math.IsNaN(data[len(data)-1]) {
+		t.Errorf("NaN sorted to %v, not the end", data[len(data)-1])
+	}
+	a, b := idx.FindFloat64Range(-0.25)
+	if a == b || data[a] != -0.25 || data[b-1] != -0.25 || data[a-1] == -0.25 || data[b] == -0.25 {
+		t.Errorf("FindFloat64Range(-0.25) found [%d,%d)", a, b)
+	}
+	if i := idx.FindFloat64(-0.3); i != a {
+		t.Errorf("FindFloat64(-0.3) found %d, want %d", i, a)
+	}
+	if a, b := idx.FindFloat64Range(-0.3); a != b {
+		t.Errorf("FindFloat64Range(-0.3) found [%d,%d), want an empty range", a, b)
+	}
+}
+
+func TestFindFloat32(t *testing.T) {
+	data := sortutil.Float32Slice{2, -1, float32(math.Inf(1)), 0.5, -1, 2, 2}
+	idx := SortWithIndex(data)
+	if i := idx.FindFloat32(0); data[i] != 0.5 {
+		t.Errorf("FindFloat32(0) found %v", data[i])
+	}
+	if a, b := idx.FindFloat32Range(2); a != 3 || b != 6 {
+		t.Errorf("FindFloat32Range(2) found [%d,%d), want [3,6)", a, b)
+	}
+	if i := idx.FindFloat32(float32(math.Inf(1))); i != 6 {
+		t.Errorf("FindFloat32(+Inf) found %d, want 6", i)
+	}
+}
+
+func TestBuildIndex(t *testing.T) {
+	names := []string{"", "a", "b", "abcdefgh", "abcdefghi"}
+	data := make(records, 10000)
+	for i := range data {
+		data[i] = record{uint64(rand.Intn(100)), names[rand.Intn(len(names))]}
+	}
+	orig := append(records(nil), data...)
+	byID := BuildIndex(data, func(i int) uint64 { return data[i].id })
+	byName := BuildIndex(data, func(i int) uint64 { return StringKey(data[i].name) })
+	for i := range data {
+		if data[i] != orig[i] {
+			t.Fatalf("BuildIndex moved data")
+		}
+	}
+
+	a, b := byID.FindUint64Range(42)
+	n := 0
+	for i := range data {
+		if data[i].id == 42 {
+			n++
+		}
+	}
+	if b-a != n {
+		t.Errorf("found %d items with id 42, want %d", b-a, n)
+	}
+	for _, p := range byID.Perm[a:b] {
+		if data[p].id != 42 {
+			t.Errorf("Perm points to id %d, want 42", data[p].id)
+		}
+	}
+
+	// ties on StringKey are broken by data.Less, which compares names
+	for i := 1; i < byName.Len(); i++ {
+		if data[byName.Position(i-1)].name > data[byName.Position(i)].name {
+			t.Fatalf("names out of order at %d", i)
+		}
+	}
+
+	// string lookups go through Perm too
+	words := sortutil.StringSlice{"b", "abcdefghi", "a", "abcdefgh", "b", ""}
+	idx := BuildIndex(words, func(i int) uint64 { return StringKey(words[i]) })
+	a, b = idx.FindStringRange("b")
+	if b-a != 2 || words[idx.Position(a)] != "b" || words[idx.Position(a+1)] != "b" {
+		t.Errorf("FindStringRange(b) found [%d,%d)", a, b)
+	}
+	if i := idx.FindString("abcdefghi"); words[idx.Position(i)] != "abcdefghi" {
+		t.Errorf("FindString(abcdefghi) found %q", words[idx.Position(i)])
+	}
+}
+
+func TestFindPrefix(t *testing.T) {
+	words := []string{"", "a", "ab", "ab\x00", "abc", "abcdefgh", "abcdefghi", "abcdefghij", "abd", "b", "\xff\xff", "\xff\xff\xff"}
+	data := make(sortutil.StringSlice, 1000)
+	for i := range data {
+		data[i] = words[rand.Intn(len(words))]
+	}
+	bytesData := make(sortutil.BytesSlice, len(data))
+	for i := range data {
+		bytesData[i] = []byte(data[i])
+	}
+	idx := SortWithIndex(data)
+	bytesIdx := SortWithIndex(bytesData)
+	prefixes := append(words, "abcdefghik", "ac", "\xff", "zz")
+	for _, p := range prefixes {
+		// linear scan for the expected answer
+		a := 0
+		for a < len(data) && data[a] < p {
+			a++
+		}
+		b := a
+		for b < len(data) && strings.HasPrefix(data[b], p) {
+			b++
+		}
+		if aa, bb := idx.FindPrefix(p); aa != a || bb != b {
+			t.Errorf("FindPrefix(%q) = %d, %d; want %d, %d", p, aa, bb, a, b)
+		}
+		if aa, bb := bytesIdx.FindPrefix(p); aa != a || bb != b {
+			t.Errorf("FindPrefix(%q) on []byte data = %d, %d; want %d, %d", p, aa, bb, a, b)
+		}
+	}
+}
+
+// TestFindStringLongKeys looks up keys that share their first 8 bytes, so
+// Find* has to compare whole strings, not just the uint64 keys.
+func TestFindStringLongKeys(t *testing.T) {
+	words := []string{"abcdefgh", "abcdefgh0", "abcdefgh1", "abcdefgh1", "abcdefgh2", "abcdefghz"}
+	data := make(sortutil.StringSlice, len(words))
+	copy(data, words)
+	bytesData := make(sortutil.BytesSlice, len(words))
+	for i, w := range words {
+		bytesData[i] = []byte(w)
+	}
+	idx := SortWithIndex(data)
+	bytesIdx := SortWithIndex(bytesData)
+	keys := append(words, "abcdefgh00", "abcdefgh3", "abcdefgi")
+	for _, k := range keys {
+		a := sort.SearchStrings(words, k)
+		b := a
+		for b < len(words) && words[b] == k {
+			b++
+		}
+		if got := idx.FindString(k); got != a {
+			t.Errorf("FindString(%q) = %d, want %d", k, got, a)
+		}
+		if aa, bb := idx.FindStringRange(k); aa != a || bb != b {
+			t.Errorf("FindStringRange(%q) = %d, %d; want %d, %d", k, aa, bb, a, b)
+		}
+		if got := bytesIdx.FindBytes([]byte(k)); got != a {
+			t.Errorf("FindBytes(%q) = %d, want %d", k, got, a)
+		}
+		if aa, bb := bytesIdx.FindBytesRange([]byte(k)); aa != a || bb != b {
+			t.Errorf("FindBytesRange(%q) = %d, %d; want %d, %d", k, aa, bb, a, b)
+		}
+	}
+}
+
+func TestCount(t *testing.T) {
+	words := []string{"a", "b", "b", "bcdefghij", "bcdefghij", "bcdefghij", "bcdefghik", "c"}
+	data := make(sortutil.StringSlice, len(words))
+	copy(data, words)
+	idx := SortWithIndex(data)
+	bytesData := make(sortutil.BytesSlice, len(words))
+	for i, w := range words {
+		bytesData[i] = []byte(w)
+	}
+	bytesIdx := SortWithIndex(bytesData)
+	tests := []struct {
+		key  string
+		want int
+	}{
+		{"", 0}, {"a", 1}, {"b", 2}, {"bb", 0}, {"bcdefghij", 3},
+		{"bcdefghik", 1}, {"bcdefghi", 0}, {"c", 1}, {"d", 0},
+	}
+	for _, test := range tests {
+		if got := idx.CountString(test.key); got != test.want {
+			t.Errorf("CountString(%q) = %d, want %d", test.key, got, test.want)
+		}
+		if got := idx.CountBytes([]byte(test.key)); got != test.want {
+			t.Errorf("CountBytes(%q) = %d, want %d", test.key, got, test.want)
+		}
+		if got := bytesIdx.CountString(test.key); got != test.want {
+			t.Errorf("CountString(%q) on []byte data = %d, want %d", test.key, got, test.want)
+		}
+		if got := bytesIdx.CountBytes([]byte(test.key)); got != test.want {
+			t.Errorf("CountBytes(%q) on []byte data = %d, want %d", test.key, got, test.want)
+		}
+	}
+	if got := idx.CountUint64(StringKey("bcdefghi")); got != 4 {
+		t.Errorf("CountUint64 = %d, want 4", got)
+	}
+	if got := idx.CountUint64(StringKey("z")); got != 0 {
+		t.Errorf("CountUint64 of missing key = %d, want 0", got)
+	}
+}
+
+func TestCompareStringToBytes(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"", "", 0},
+		{"abc", "abc", 0},
+		{"", "a", -1},      // a is a prefix of b
+		{"ab", "abc", -1},  // a is a prefix of b
+		{"a", "", 1},       // b is a prefix of a
+		{"abc", "ab", 1},   // b is a prefix of a
+		{"abd", "abc", 1},  // differ in the last byte
+		{"abc", "abd", -1}, // differ in the last byte
+		{"b", "abc", 1},    // differ in the first byte, a shorter
+		{"abc", "b", -1},   // differ in the first byte, b shorter
+		{"\xff", "\x00\x00", 1},
+	}
+	for _, test := range tests {
+		if got := CompareStringToBytes(test.a, []byte(test.b)); got != test.want {
+			t.Errorf("CompareStringToBytes(%q, %q) = %d, want %d", test.a, test.b, got, test.want)
+		}
+		if got := CompareBytesToString([]byte(test.b), test.a); got != -test.want {
+			t.Errorf("CompareBytesToString(%q, %q) = %d, want %d", test.b, test.a, got, -test.want)
+		}
+	}
+
+	// with []byte data, a query that's a prefix of a stored key used to
+	// panic
+	data := sortutil.BytesSlice{[]byte("ab"), []byte("abc"), []byte("abcdefghij")}
+	idx := SortWithIndex(data)
+	if i := idx.FindString("abcdefghi"); i != 2 {
+		t.Errorf("FindString(prefix of stored key) = %d, want 2", i)
+	}
+	if a, b := idx.FindStringRange("abcdefghi"); a != 2 || b != 2 {
+		t.Errorf("FindStringRange(prefix of stored key) = %d, %d, want 2, 2", a, b)
+	}
+}
+
+func TestSummarizeExactSize(t *testing.T) {
+	for _, size := range []int{0, 1, 63, 64, 65, 100, 4032, 4033, 4095, 4096, 4097, 1 << 18, 1<<18 + 1, 300000} {
+		keys := make([]uint64, size)
+		for i := range keys {
+			keys[i] = uint64(i) * 2
+		}
+		idx := &Index{Keys: keys}
+		idx.Summarize()
+		if cap(idx.Summary) != len(idx.Summary) {
+			t.Errorf("size %d: summary has len %d, cap %d", size, len(idx.Summary), cap(idx.Summary))
+		}
+		for _, i := range []int{0, size / 3, size - 1} {
+			if i < 0 || i >= size {
+				continue
+			}
+			if got := idx.FindUint64(uint64(i) * 2); got != i {
+				t.Errorf("size %d: FindUint64(%d) = %d, want %d", size, i*2, got, i)
+			}
+			if got := idx.FindUint64(uint64(i)*2 + 1); got != i+1 {
+				t.Errorf("size %d: FindUint64(%d) = %d, want %d", size, i*2+1, got, i+1)
+			}
+		}
+	}
+}
+
+// TestSummaryAgrees checks lookups with and without a Summary agree, on
+// random key sets with lots of duplicates and keys at the ends of the
+// uint64 range.
+func TestSummaryAgrees(t *testing.T) {
+	const max = ^uint64(0)
+	gens := []func(r int) uint64{
+		func(r int) uint64 { return uint64(r) },             // dense, many dups
+		func(r int) uint64 { return max - uint64(r) },       // near the top
+		func(r int) uint64 { return uint64(r) << 60 },       // few distinct keys
+		func(r int) uint64 { return max },                   // all max
+		func(r int) uint64 { return 0 },                     // all zero
+		func(r int) uint64 { return []uint64{0, max}[r&1] }, // only the ends
+	}
+	for trial := 0; trial < 200; trial++ {
+		size := rand.Intn(10000)
+		if trial < 10 {
+			size = []int{0, 1, 63, 64, 65, 4095, 4096, 4097, 64 * 64 * 2, 64*64*2 + 1}[trial]
+		}
+		gen := gens[trial%len(gens)]
+		keys := make(sortutil.Uint64Slice, size)
+		for i := range keys {
+			keys[i] = gen(rand.Intn(16))
+		}
+		keys.Sort()
+		plain := &Index{Keys: keys}
+		summarized := &Index{Keys: keys}
+		summarized.SummarizeLevelBits([]int{6, 3, 9, 1}[trial%4])
+
+		queries := []uint64{0, 1, max - 1, max}
+		for i := 0; i < 20 && size > 0; i++ {
+			k := keys[rand.Intn(size)]
+			queries = append(queries, k, k-1, k+1)
+		}
+		for _, q := range queries {
+			want := sort.Search(size, func(i int) bool { return keys[i] >= q })
+			if got := plain.FindUint64(q); got != want {
+				t.Fatalf("size %d: FindUint64(%#x) = %d, want %d", size, q, got, want)
+			}
+			if got := summarized.FindUint64(q); got != want {
+				t.Fatalf("size %d: summarized FindUint64(%#x) = %d, want %d", size, q, got, want)
+			}
+			a, b := plain.FindUint64Range(q)
+			sa, sb := summarized.FindUint64Range(q)
+			if a != sa || b != sb {
+				t.Fatalf("size %d: FindUint64Range(%#x) = %d, %d; summarized %d, %d", size, q, a, b, sa, sb)
+			}
+			for i := a; i < b; i++ {
+				if keys[i] != q {
+					t.Fatalf("size %d: FindUint64Range(%#x) = %d, %d includes %#x", size, q, a, b, keys[i])
+				}
+			}
+			if (a > 0 && keys[a-1] == q) || (b < size && keys[b] == q) {
+				t.Fatalf("size %d: FindUint64Range(%#x) = %d, %d misses some matches", size, q, a, b)
+			}
+		}
+	}
+
+	// every key is the max
+	keys := make([]uint64, 5000)
+	for i := range keys {
+		keys[i] = max
+	}
+	idx := &Index{Keys: keys}
+	idx.Summarize()
+	if a, b := idx.FindUint64Range(max); a != 0 || b != len(keys) {
+		t.Errorf("all keys max: FindUint64Range(max) = %d, %d; want 0, %d", a, b, len(keys))
+	}
+	if a, b := idx.FindUint64Range(0); a != 0 || b != 0 {
+		t.Errorf("all keys max: FindUint64Range(0) = %d, %d; want 0, 0", a, b)
+	}
+}
+
+func TestSortWithIndexDesc(t *testing.T) {
+	words := make(sortutil.StringSlice, 10000)
+	for i := range words {
+		words[i] = strings.Repeat("ab", rand.Intn(6)) + string(rune('a'+rand.Intn(26)))
+	}
+	words[0], words[1] = "", "abababababababz"
+	for _, summarize := range []bool{false, true} {
+		data := append(sortutil.StringSlice(nil), words...)
+		idx := SortWithIndexDesc(data)
+		if summarize {
+			idx.Summarize()
+		}
+		if !sort.IsSorted(sort.Reverse(data)) {
+			t.Fatalf("data didn't sort descending")
+		}
+		if !sort.IsSorted(idx) {
+			t.Errorf("Index isn't sorted by its own Less")
+		}
+		for _, w := range []string{"", "a", "abab", "ababz", "abababababababz", "zz", "abc"} {
+			want := sort.Search(len(data), func(i int) bool { return data[i] <= w })
+			if got := idx.FindString(w); got != want {
+				t.Errorf("summarize=%v: FindString(%q) = %d, want %d", summarize, w, got, want)
+			}
+			wantEnd := sort.Search(len(data), func(i int) bool { return data[i] < w })
+			if a, b := idx.FindStringRange(w); a != want || b != wantEnd {
+				t.Errorf("summarize=%v: FindStringRange(%q) = [%d,%d), want [%d,%d)", summarize, w, a, b, want, wantEnd)
+			}
+			a, b := idx.FindPrefix(w)
+			for i := range data {
+				if strings.HasPrefix(data[i], w) != (i >= a && i < b) {
+					t.Fatalf("summarize=%v: FindPrefix(%q) = [%d,%d), wrong about %q at %d", summarize, w, a, b, data[i], i)
+				}
+			}
+		}
+	}
+
+	nums := sortutil.Uint64Slice{5, 1, ^uint64(0), 0, 5, 3}
+	idx := SortWithIndexDesc(nums)
+	if a, b := idx.FindUint64Range(5); a != 1 || b != 3 {
+		t.Errorf("FindUint64Range(5) = [%d,%d), want [1,3) in %v", a, b, nums)
+	}
+	if a, b := idx.FindUint64Range(0); a != 5 || b != 6 {
+		t.Errorf("FindUint64Range(0) = [%d,%d), want [5,6)", a, b)
+	}
+	if i := idx.FindUint64(4); i != 3 {
+		t.Errorf("FindUint64(4) = %d, want 3", i)
+	}
+}
+
+func TestSortWithIndexInto(t *testing.T) {
+	buf := make([]uint64, 0, 1000)
+	for _, n := range []int{1000, 10, 2000} {
+		data := make(sortutil.IntSlice, n)
+		for i := range data {
+			data[i] = rand.Int()
+		}
+		idx := SortWithIndexInto(data, buf)
+		if !sort.IntsAreSorted(data) || len(idx.Keys) != n {
+			t.Fatalf("n=%d: not sorted, or %d keys", n, len(idx.Keys))
+		}
+		if reused := &idx.Keys[0] == &buf[:1][0]; reused != (n <= cap(buf)) {
+			t.Errorf("n=%d: reused buffer is %v with capacity %d", n, reused, cap(buf))
+		}
+	}
+	data := sortutil.IntSlice{3, 1, 2}
+	with := testing.AllocsPerRun(10, func() { SortWithIndexInto(data, buf) })
+	without := testing.AllocsPerRun(10, func() { SortWithIndex(data) })
+	if with != without-1 {
+		t.Errorf("%v allocations per sort with a buffer, %v without", with, without)
+	}
+}
+
+func TestFindStringApprox(t *testing.T) {
+	data := sortutil.StringSlice{"prefix01-b", "prefix01-a", "prefix02", "a"}
+	idx := SortWithIndex(data)
+	idx.Data = nil
+	if i := idx.FindStringApprox("prefix01-b"); i != 1 {
+		t.Errorf("FindStringApprox of a long key found %d, want the run's start 1", i)
+	}
+	if i := idx.FindStringApprox("prefix02"); i != 3 {
+		t.Errorf("FindStringApprox(prefix02) found %d, want 3", i)
+	}
+	if i := idx.FindStringApprox("b"); i != 1 {
+		t.Errorf("FindStringApprox(b) found %d, want 1", i)
+	}
+}
+
+func TestFreeze(t *testing.T) {
+	words := make(sortutil.StringSlice, 10000)
+	for i := range words {
+		words[i] = strings.Repeat("x", rand.Intn(12)) + string(rune('a'+rand.Intn(26)))
+	}
+	idx := SortWithIndex(words)
+	idx.Summarize()
+	keys := append([]uint64(nil), idx.Keys...)
+	data := append(sortutil.StringSlice(nil), words...)
+	f := idx.Freeze()
+
+	queries := []string{"", "a", "xm", "xxxxxxxxxxz", "zzz"}
+	want := make([][3]int, len(queries))
+	for i, q := range queries {
+		a, b := idx.FindStringRange(q)
+		want[i] = [3]int{idx.FindString(q), a, b}
+	}
+
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for n := 0; n < 100; n++ {
+				for i, q := range queries {
+					a, b := f.FindStringRange(q)
+					if got := [3]int{f.FindString(q), a, b}; got != want[i] {
+						t.Errorf("frozen index found %v for %q, want %v", got, q, want[i])
+						return
+					}
+					f.FindPrefix(q)
+					f.CountBytes([]byte(q))
+					f.FindUint64(StringKey(q))
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	if f.Len() != len(words) {
+		t.Errorf("frozen Len was %d, want %d", f.Len(), len(words))
+	}
+	for i := range keys {
+		if idx.Keys[i] != keys[i] || words[i] != data[i] {
+			t.Fatal("searching a FrozenIndex changed the Index")
+		}
+	}
+}
+
+// wideRecord is a struct, expensive to swap, keyed by name.
+type wideRecord struct {
+	name    string
+	payload [8]uint64
+}
+
+type wideRecords struct {
+	r     []wideRecord
+	swaps int64 // updated atomically: parallel sorts swap concurrently
+}
+
+func (r *wideRecords) Len() int           { return len(r.r) }
+func (r *wideRecords) Less(i, j int) bool { return r.r[i].payload[0] < r.r[j].payload[0] }
+func (r *wideRecords) Swap(i, j int)      { r.r[i], r.r[j] = r.r[j], r.r[i]; atomic.AddInt64(&r.swaps, 1) }
+func (r *wideRecords) Key(i int) string   { return r.r[i].name }
+
+func makeWideRecords(n int) *wideRecords {
+	r := &wideRecords{r: make([]wideRecord, n)}
+	for i := range r.r {
+		r.r[i].name = strconv.Itoa(rand.Intn(n))
+		r.r[i].payload[0] = uint64(i)
+	}
+	return r
+}
+
+func TestSortWithIndexPermute(t *testing.T) {
+	for _, n := range []int{0, 1, 10, 10000} {
+		r := makeWideRecords(n)
+		r2 := &wideRecords{r: append([]wideRecord(nil), r.r...)}
+		idx := SortWithIndexPermute(r)
+		if idx.Perm != nil || idx.Data != sort.Interface(r) {
+			t.Fatalf("n=%d: SortWithIndexPermute left a Perm or other Data", n)
+		}
+		if r.swaps >= int64(n) && n > 0 {
+			t.Errorf("n=%d: %d swaps, more than n-1", n, r.swaps)
+		}
+		SortWithIndex(r2)
+		for i := range r.r {
+			if r.r[i] != r2.r[i] {
+				t.Fatalf("n=%d: SortWithIndexPermute and SortWithIndex differ at %d", n, i)
+			}
+		}
+		if n > 0 && idx.FindString(r.r[n/2].name) > n/2 {
+			t.Errorf("n=%d: FindString missed", n)
+		}
+	}
+}
+
+func benchWideRecords(b *testing.B, sortFunc func(sort.Interface) *Index) {
+	const n = 1e6
+	orig := makeWideRecords(n)
+	r := &wideRecords{r: make([]wideRecord, n)}
+	swaps := int64(0)
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		copy(r.r, orig.r)
+		r.swaps = 0
+		b.StartTimer()
+		sortFunc(r)
+		swaps += r.swaps
+	}
+	b.ReportMetric(float64(swaps)/float64(b.N), "swaps/op")
+}
+
+func BenchmarkSortWithIndexStructs1e6(b *testing.B) { benchWideRecords(b, SortWithIndex) }
+func BenchmarkSortWithIndexPermuteStructs1e6(b *testing.B) {
+	benchWideRecords(b, SortWithIndexPermute)
+}
+
+// named is sorted by name only, so items with the same name tie in Less
+// and a stable sort must keep them in seq order.
+type named struct {
+	name string
+	seq  int
+}
+
+type namedSlice []named
+
+func (n namedSlice) Len() int           { return len(n) }
+func (n namedSlice) Less(i, j int) bool { return n[i].name < n[j].name }
+func (n namedSlice) Swap(i, j int)      { n[i], n[j] = n[j], n[i] }
+
+func TestIndexStdlibSort(t *testing.T) {
+	makeIndex := func(perm bool) (*Index, namedSlice) {
+		data := make(namedSlice, 5000)
+		for i := range data {
+			// long shared prefixes, so keys tie and Data.Less decides
+			data[i] = named{"prefix" + strconv.Itoa(rand.Intn(1000)), i}
+		}
+		idx := &Index{Keys: make([]uint64, len(data)), Data: data}
+		for i := range data {
+			idx.Keys[i] = StringKey(data[i].name)
+		}
+		if perm {
+			idx.Perm = make([]int, len(data))
+			for i := range idx.Perm {
+				idx.Perm[i] = i
+			}
+		}
+		return idx, data
+	}
+
+	for _, perm := range []bool{false, true} {
+		for _, desc := range []bool{false, true} {
+			for _, stable := range []bool{false, true} {
+				idx, data := makeIndex(perm)
+				idx.Descending = desc
+				if stable {
+					sort.Stable(idx)
+				} else {
+					sort.Sort(idx)
+				}
+				for i := 1; i < idx.Len(); i++ {
+					prev, cur := data[idx.Position(i-1)], data[idx.Position(i)]
+					if idx.Keys[i] != StringKey(cur.name) {
+						t.Fatalf("perm=%v desc=%v stable=%v: Keys and Data out of step at %d", perm, desc, stable, i)
+					}
+					if (!desc && prev.name > cur.name) || (desc && prev.name < cur.name) {
+						t.Fatalf("perm=%v desc=%v stable=%v: %q before %q", perm, desc, stable, prev.name, cur.name)
+					}
+					if stable && prev.name == cur.name && prev.seq > cur.seq {
+						t.Fatalf("perm=%v desc=%v: sort.Stable reordered equal items", perm, desc)
+					}
+				}
+				idx.Summarize()
+				if i := idx.FindUint64(StringKey("prefix5")); !desc && data[idx.Position(i)].name < "prefix5" {
+					t.Errorf("perm=%v stable=%v: FindUint64 after sorting found %q", perm, stable, data[idx.Position(i)].name)
+				}
+			}
+		}
+	}
+
+	// sort.Sort on an Index agrees with SortWithIndex
+	_, data := makeIndex(false)
+	data2 := append(namedSlice(nil), data...)
+	byStd := &Index{Keys: make([]uint64, len(data)), Data: data}
+	for i := range data {
+		byStd.Keys[i] = StringKey(data[i].name)
+	}
+	sort.Stable(byStd)
+	byRadix := &Index{Keys: make([]uint64, len(data2)), Data: data2}
+	for i := range data2 {
+		byRadix.Keys[i] = StringKey(data2[i].name)
+	}
+	sorts.ByUint64(byRadix)
+	for i := range data {
+		if data[i].name != data2[i].name || byStd.Keys[i] != byRadix.Keys[i] {
+			t.Fatalf("sort.Stable and sorts.ByUint64 disagree at %d", i)
+		}
+	}
+}
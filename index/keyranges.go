@@ -0,0 +1,48 @@
+// Copyright 2015 Randall Farmer. All rights reserved.
+
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+package index
+
+import (
+	"sort"
+
+	"github.com/twotwotwo/sorts"
+)
+
+// KeyRanges maps each distinct key in sorted data to the range of items
+// with that key. It's more compact than a map and searches a small,
+// cache-friendly array.
+type KeyRanges struct {
+	Keys   []uint64 // distinct keys, ascending
+	Starts []int    // Starts[i] is where Keys[i] begins; last entry is data.Len()
+}
+
+// BuildKeyRanges sorts data with sorts.ByUint64, then records each
+// distinct key and where its run of items starts.
+func BuildKeyRanges(data sorts.Uint64Interface) *KeyRanges {
+	sorts.ByUint64(data)
+	l := data.Len()
+	kr := &KeyRanges{}
+	for i := 0; i < l; i++ {
+		k := data.Key(i)
+		if i == 0 || k != kr.Keys[len(kr.Keys)-1] {
+			kr.Keys = append(kr.Keys, k)
+			kr.Starts = append(kr.Starts, i)
+		}
+	}
+	kr.Starts = append(kr.Starts, l)
+	return kr
+}
+
+// Lookup returns the range [start,end) of items whose key equals key. If
+// there are none, ok is false and start and end are where the key would
+// be inserted.
+func (kr *KeyRanges) Lookup(key uint64) (start, end int, ok bool) {
+	i := sort.Search(len(kr.Keys), func(i int) bool { return kr.Keys[i] >= key })
+	if i == len(kr.Keys) || kr.Keys[i] != key {
+		return kr.Starts[i], kr.Starts[i], false
+	}
+	return kr.Starts[i], kr.Starts[i+1], true
+}
@@ -0,0 +1,51 @@
+// Copyright 2015 Randall Farmer. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package index_test
+
+import (
+	"math/rand"
+	"testing"
+
+	. "github.com/twotwotwo/sorts/index"
+	"github.com/twotwotwo/sorts/sortutil"
+)
+
+func TestKeyRanges(t *testing.T) {
+	data := make(sortutil.Uint64Slice, 10000)
+	for i := range data {
+		data[i] = uint64(rand.Intn(1000)) * 2 // only even keys, so odd ones are absent
+	}
+	data[0] = 0
+	data[1] = ^uint64(0) - 1
+	kr := BuildKeyRanges(data)
+	if len(kr.Starts) != len(kr.Keys)+1 {
+		t.Fatalf("got %d starts for %d keys", len(kr.Starts), len(kr.Keys))
+	}
+
+	queries := []uint64{0, 1, ^uint64(0) - 1, ^uint64(0)}
+	for i := 0; i < 100; i++ {
+		queries = append(queries, uint64(rand.Intn(2002)))
+	}
+	for _, q := range queries {
+		// linear scan for the expected answer
+		start := 0
+		for start < len(data) && data[start] < q {
+			start++
+		}
+		end := start
+		for end < len(data) && data[end] == q {
+			end++
+		}
+		a, b, ok := kr.Lookup(q)
+		if a != start || b != end || ok != (end > start) {
+			t.Errorf("Lookup(%d) = %d, %d, %v; want %d, %d, %v", q, a, b, ok, start, end, end > start)
+		}
+	}
+
+	empty := BuildKeyRanges(sortutil.Uint64Slice(nil))
+	if a, b, ok := empty.Lookup(0); a != 0 || b != 0 || ok {
+		t.Errorf("Lookup on empty KeyRanges = %d, %d, %v", a, b, ok)
+	}
+}
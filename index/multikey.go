@@ -0,0 +1,89 @@
+// Copyright 2015 Randall Farmer. All rights reserved.
+
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+package index
+
+import (
+	"sort"
+
+	"github.com/twotwotwo/sorts"
+)
+
+// multiKey holds a uint64 key column per key func, and sorts data by
+// column col, swapping all the columns along with data.
+type multiKey struct {
+	cols [][]uint64
+	col  int
+	data sort.Interface
+}
+
+func (m *multiKey) Len() int { return m.data.Len() }
+
+// Less compares only the current column, so stable sorts leave items with
+// equal keys in the order earlier passes left them in.
+func (m *multiKey) Less(i, j int) bool { return m.cols[m.col][i] < m.cols[m.col][j] }
+
+func (m *multiKey) Swap(i, j int) {
+	for _, c := range m.cols {
+		c[i], c[j] = c[j], c[i]
+	}
+	m.data.Swap(i, j)
+}
+
+func (m *multiKey) Key(i int) uint64 { return m.cols[m.col][i] }
+
+// tied reports whether items i and j have equal keys in every column.
+func (m *multiKey) tied(i, j int) bool {
+	for _, c := range m.cols {
+		if c[i] != c[j] {
+			return false
+		}
+	}
+	return true
+}
+
+// tieRange is the range data[a:a+n] of items tied on every key, ordered by
+// data.Less.
+type tieRange struct {
+	m    *multiKey
+	a, n int
+}
+
+func (t tieRange) Len() int           { return t.n }
+func (t tieRange) Less(i, j int) bool { return t.m.data.Less(t.a+i, t.a+j) }
+func (t tieRange) Swap(i, j int)      { t.m.Swap(t.a+i, t.a+j) }
+
+// SortWithKeys sorts data by several uint64 keys: by keys[0], then by
+// keys[1] for items with equal keys[0], and so on, using data.Less as a
+// tie-breaker for items equal on every key.
+//
+// Each key func is called once per item before anything moves, and the
+// results are kept in a column of their own, like an Index's Keys.  It then
+// stable-sorts by each column, least significant first, and finally sorts
+// runs of tied items with data.Less.  That costs 8 bytes per item per key,
+// plus the scratch space sorts.ByUint64Stable uses.
+func SortWithKeys(data sort.Interface, keys ...func(i int) uint64) {
+	l := data.Len()
+	m := &multiKey{cols: make([][]uint64, len(keys)), data: data}
+	for c, key := range keys {
+		col := make([]uint64, l)
+		for i := range col {
+			col[i] = key(i)
+		}
+		m.cols[c] = col
+	}
+	for m.col = len(keys) - 1; m.col >= 0; m.col-- {
+		sorts.ByUint64Stable(m)
+	}
+	a := 0
+	for b := 1; b <= l; b++ {
+		if b == l || !m.tied(a, b) {
+			if b-a > 1 {
+				sort.Sort(tieRange{m, a, b - a})
+			}
+			a = b
+		}
+	}
+}
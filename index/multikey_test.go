@@ -0,0 +1,48 @@
+// Copyright 2015 Randall Farmer. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package index_test
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+
+	. "github.com/twotwotwo/sorts/index"
+)
+
+type record struct {
+	id   uint64
+	name string
+}
+
+// records sorts by name in Less, for SortWithKeys to fall back on when
+// names tie on StringKey.
+type records []record
+
+func (r records) Len() int           { return len(r) }
+func (r records) Less(i, j int) bool { return r[i].name < r[j].name }
+func (r records) Swap(i, j int)      { r[i], r[j] = r[j], r[i] }
+
+func TestSortWithKeys(t *testing.T) {
+	names := []string{"", "a", "b", "abcdefgh", "abcdefghi", "abcdefghj"}
+	for _, size := range []int{0, 1, 10, 10000} {
+		data := make(records, size)
+		for i := range data {
+			data[i] = record{uint64(rand.Intn(10)), names[rand.Intn(len(names))]}
+		}
+		SortWithKeys(data,
+			func(i int) uint64 { return data[i].id },
+			func(i int) uint64 { return StringKey(data[i].name) },
+		)
+		if !sort.SliceIsSorted(data, func(i, j int) bool {
+			if data[i].id != data[j].id {
+				return data[i].id < data[j].id
+			}
+			return data[i].name < data[j].name
+		}) {
+			t.Errorf("size %d: not sorted by id, then name", size)
+		}
+	}
+}
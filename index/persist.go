@@ -0,0 +1,145 @@
+// Copyright 2015 Randall Farmer. All rights reserved.
+
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+package index
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"io"
+)
+
+// The on-disk format is the magic string, a version, the lengths of Keys
+// and Summary, the Summary's levelBits, then the contents of Keys and
+// Summary, all integers little-endian uint64s.  Version 1 files lack the
+// levelBits, which was always defaultLevelBits then.
+const fileMagic = "sortsidx"
+const fileVersion = 2
+
+// maxLen is the most keys a slice can hold.
+const maxLen = uint64(^uint(0) >> 1)
+
+// readChunk caps the room ReadIndex makes for Keys or Summary before
+// reading them; past that, slices grow as values arrive, so a corrupt
+// length can't make it allocate much more than the file holds.
+const readChunk = 1 << 16
+
+// ErrBadIndexFile is returned by ReadIndex for input that isn't a saved
+// Index, or is from an unknown version of this package.
+var ErrBadIndexFile = errors.New("index: not an index file, or unknown version")
+
+// WriteTo saves idx's Keys and Summary to w, so ReadIndex can load them
+// back without re-sorting. Data isn't saved, and neither is Perm, so an
+// Index from BuildIndex can't be saved this way.  Descending isn't saved
+// either; set it again on the loaded Index.
+func (idx *Index) WriteTo(w io.Writer) (n int64, err error) {
+	bw := bufio.NewWriter(w)
+	var buf [8]byte
+	put := func(v uint64) {
+		if err != nil {
+			return
+		}
+		binary.LittleEndian.PutUint64(buf[:], v)
+		var m int
+		m, err = bw.Write(buf[:])
+		n += int64(m)
+	}
+	m, err := bw.WriteString(fileMagic)
+	n += int64(m)
+	put(fileVersion)
+	put(uint64(len(idx.Keys)))
+	summaryLen := uint64(len(idx.Summary))
+	if idx.Summary == nil {
+		summaryLen = ^uint64(0) // so ReadIndex restores a nil Summary
+	}
+	put(summaryLen)
+	put(uint64(idx.levelBits()))
+	for _, k := range idx.Keys {
+		put(k)
+	}
+	for _, k := range idx.Summary {
+		put(k)
+	}
+	if err != nil {
+		return n, err
+	}
+	return n, bw.Flush()
+}
+
+// ReadIndex loads an Index saved by WriteTo. Its Data is nil, so the caller
+// has to set it to the same data, in the same order, before using methods
+// like Len, Swap, or FindString that look at Data.  FindUint64 and
+// FindUint64Range work without Data.  A file cut short returns
+// io.ErrUnexpectedEOF.
+func ReadIndex(r io.Reader) (*Index, error) {
+	br := bufio.NewReader(r)
+	var buf [8]byte
+	if _, err := io.ReadFull(br, buf[:len(fileMagic)]); err != nil {
+		return nil, err
+	}
+	if string(buf[:len(fileMagic)]) != fileMagic {
+		return nil, ErrBadIndexFile
+	}
+	get := func() (uint64, error) {
+		if _, err := io.ReadFull(br, buf[:]); err != nil {
+			if err == io.EOF {
+				err = io.ErrUnexpectedEOF
+			}
+			return 0, err
+		}
+		return binary.LittleEndian.Uint64(buf[:]), nil
+	}
+	header := [4]uint64{3: defaultLevelBits}
+	for i := range header {
+		if i == 3 && header[0] == 1 {
+			break // version 1 has no levelBits
+		}
+		v, err := get()
+		if err != nil {
+			return nil, err
+		}
+		header[i] = v
+	}
+	version, keysLen, summaryLen, levelBits := header[0], header[1], header[2], header[3]
+	if version < 1 || version > fileVersion || keysLen > maxLen ||
+		levelBits < 1 || levelBits > maxLevelBits {
+		return nil, ErrBadIndexFile
+	}
+	// read n uint64s, allocating only as fast as they actually arrive
+	read := func(n uint64) ([]uint64, error) {
+		s := make([]uint64, 0, min(n, readChunk))
+		for uint64(len(s)) < n {
+			v, err := get()
+			if err != nil {
+				return nil, err
+			}
+			s = append(s, v)
+		}
+		return s, nil
+	}
+
+	keys, err := read(keysLen)
+	if err != nil {
+		return nil, err
+	}
+	idx := &Index{Keys: keys, summaryBits: int(levelBits)}
+	if summaryLen == ^uint64(0) {
+		return idx, nil
+	}
+	// lookups trust the Summary's length, so it has to be exactly what
+	// SummarizeLevelBits would make
+	want := 0
+	for level := 1; level <= summaryLevels(len(keys), int(levelBits)); level++ {
+		want += summaryLevelLen(len(keys), level, int(levelBits))
+	}
+	if summaryLen != uint64(want) {
+		return nil, ErrBadIndexFile
+	}
+	if idx.Summary, err = read(summaryLen); err != nil {
+		return nil, err
+	}
+	return idx, nil
+}
@@ -0,0 +1,115 @@
+// Copyright 2015 Randall Farmer. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package index_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"math/rand"
+	"strconv"
+	"testing"
+
+	. "github.com/twotwotwo/sorts/index"
+	"github.com/twotwotwo/sorts/sortutil"
+)
+
+func TestWriteReadIndex(t *testing.T) {
+	for _, n := range []int{0, 10, 10000} {
+		for _, levelBits := range []int{0, 6, 3} {
+			data := make(sortutil.StringSlice, n)
+			for i := range data {
+				data[i] = strconv.Itoa(rand.Intn(n + 1))
+			}
+			idx := SortWithIndex(data)
+			switch levelBits {
+			case 6:
+				idx.Summarize()
+			case 3:
+				idx.SummarizeLevelBits(levelBits)
+			}
+			var buf bytes.Buffer
+			written, err := idx.WriteTo(&buf)
+			if err != nil || written != int64(buf.Len()) {
+				t.Fatalf("WriteTo returned %d, %v; wrote %d", written, err, buf.Len())
+			}
+			idx2, err := ReadIndex(&buf)
+			if err != nil {
+				t.Fatalf("ReadIndex failed: %v", err)
+			}
+			if len(idx2.Keys) != len(idx.Keys) || len(idx2.Summary) != len(idx.Summary) ||
+				(idx2.Summary == nil) != (idx.Summary == nil) || idx2.Data != nil {
+				t.Fatalf("read back a different index")
+			}
+			for i := range idx.Keys {
+				if idx.Keys[i] != idx2.Keys[i] {
+					t.Fatalf("read back different keys")
+				}
+			}
+			for i := range idx.Summary {
+				if idx.Summary[i] != idx2.Summary[i] {
+					t.Fatalf("read back different summary")
+				}
+			}
+			if n > 0 && idx2.FindUint64(idx.Keys[n/2]) != idx.FindUint64(idx.Keys[n/2]) {
+				t.Errorf("FindUint64 on detached index differed")
+			}
+			if n > 0 && idx2.FindStringApprox(data[n/2]) != idx.FindString(data[n/2]) {
+				t.Errorf("FindStringApprox of a short key on detached index differed from FindString")
+			}
+			if n > 0 && idx2.FindBytesApprox([]byte(data[n/2])) != idx.FindString(data[n/2]) {
+				t.Errorf("FindBytesApprox of a short key on detached index differed from FindString")
+			}
+			idx2.Data = data
+			if n > 0 && idx2.FindString(data[n/2]) != idx.FindString(data[n/2]) {
+				t.Errorf("FindString on reattached index differed")
+			}
+		}
+	}
+
+	if _, err := ReadIndex(bytes.NewBufferString("not an index at all")); err != ErrBadIndexFile {
+		t.Errorf("reading garbage returned %v", err)
+	}
+	var buf bytes.Buffer
+	SortWithIndex(sortutil.StringSlice{"a", "b"}).WriteTo(&buf)
+	if _, err := ReadIndex(bytes.NewReader(buf.Bytes()[:buf.Len()-1])); err != io.ErrUnexpectedEOF {
+		t.Errorf("reading truncated index returned %v", err)
+	}
+
+	// a version 1 file, from before levelBits was saved
+	v1 := []byte("sortsidx")
+	for _, v := range []uint64{1, 2, ^uint64(0), 10, 20} {
+		v1 = binary.LittleEndian.AppendUint64(v1, v)
+	}
+	idx, err := ReadIndex(bytes.NewReader(v1))
+	if err != nil || len(idx.Keys) != 2 || idx.Summary != nil || idx.FindUint64(20) != 1 {
+		t.Errorf("reading version 1 index returned %v, %v", idx, err)
+	}
+
+	// a header claiming far more keys than follow
+	huge := []byte("sortsidx")
+	for _, v := range []uint64{2, 1 << 59, ^uint64(0), 6, 10, 20} {
+		huge = binary.LittleEndian.AppendUint64(huge, v)
+	}
+	if _, err := ReadIndex(bytes.NewReader(huge)); err != io.ErrUnexpectedEOF {
+		t.Errorf("reading index with huge key count returned %v", err)
+	}
+
+	// a Summary shorter than the keys need
+	keys := make(sortutil.Uint64Slice, 100)
+	for i := range keys {
+		keys[i] = uint64(i)
+	}
+	summarized := SortWithIndex(keys)
+	summarized.SummarizeLevelBits(2)
+	buf.Reset()
+	summarized.WriteTo(&buf)
+	short := buf.Bytes()
+	binary.LittleEndian.PutUint64(short[len("sortsidx")+16:], uint64(len(summarized.Summary)-1))
+	short = short[:len(short)-8]
+	if _, err := ReadIndex(bytes.NewReader(short)); err != ErrBadIndexFile {
+		t.Errorf("reading index with short Summary returned %v", err)
+	}
+}
@@ -8,6 +8,14 @@
 // Package sorts does parallel radix sorts of data by (u)int64, string, or
 // []byte keys, and parallel quicksort.  See the sorts/sortutil package for
 // shortcuts for common slice types and help sorting floats.
+//
+// The sorts are deterministic: given the same input and settings, they
+// leave items with equal keys in the same order every run, however many
+// goroutines they use and whichever finishes first, because each goroutine
+// works on its own range of data and the ranges are picked by the keys
+// alone.  The order of equal items can change with settings like
+// PreferFewerPasses or Options, or between versions of this package; the
+// Stable sorts give an order that doesn't.
 package sorts
 
 import "sort" // for Interface
@@ -20,6 +28,17 @@ type Uint64Interface interface {
 	Key(i int) uint64
 }
 
+// BulkKeyInterface is a Uint64Interface that can produce all its keys in
+// one call, for collections like column stores where that's much cheaper
+// than calling Key for each item.  ByUint64 notices it and calls KeysInto
+// once, then sorts a copy of the keys alongside the data instead of
+// calling Key.
+type BulkKeyInterface interface {
+	Uint64Interface
+	// KeysInto sets dst[i] to Key(i) for every item; len(dst) is Len().
+	KeysInto(dst []uint64)
+}
+
 // Int64Interface represents a collection that can be sorted by an int64
 // key.
 type Int64Interface interface {
@@ -28,6 +47,18 @@ type Int64Interface interface {
 	Key(i int) int64
 }
 
+// KeyOnlyInterface represents a collection that can be sorted by a uint64
+// key alone.  Without a Less method, there's no way for Key and Less to
+// disagree, as they easily can for float NaNs.
+type KeyOnlyInterface interface {
+	// Len is the number of elements in the collection.
+	Len() int
+	// Swap swaps the elements with indexes i and j.
+	Swap(i, j int)
+	// Key provides a uint64 key for element i.
+	Key(i int) uint64
+}
+
 // StringInterface represents a collection that can be sorted by a string
 // key.
 type StringInterface interface {
@@ -44,7 +75,16 @@ type BytesInterface interface {
 	Key(i int) []byte
 }
 
-// Flip reverses the order of items in a sort.Interface.
+// FixedBytesInterface is a BytesInterface whose keys all have the same
+// length, like hashes, UUIDs, or big-endian 128-bit integers.
+type FixedBytesInterface interface {
+	BytesInterface
+	// KeyLen is the length of every key.
+	KeyLen() int
+}
+
+// Flip reverses the order of items in a sort.Interface.  For plain slices,
+// sortutil.ReverseInts, ReverseStrings, and the like are faster.
 func Flip(data sort.Interface) {
 	a, b := 0, data.Len()-1
 	for b > a {
@@ -0,0 +1,18 @@
+// Copyright 2015 Randall Farmer. All rights reserved.
+
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+// Package floatkey holds the float64-to-uint64 key conversion shared by
+// package sorts and sortutil, which can't import each other that way
+// round.
+package floatkey
+
+import "math"
+
+// Float64 is sortutil.Float64Key; see its docs for the order it gives.
+func Float64(f float64) uint64 {
+	b := math.Float64bits(f)
+	b ^= ^(b>>63 - 1) | (1 << 63)
+	return b
+}
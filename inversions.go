@@ -0,0 +1,74 @@
+// Copyright 2015 Randall Farmer. All rights reserved.
+
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+package sorts
+
+// ByInt64Inversions sorts data as ByInt64Stable does, and returns the
+// number of inversions in data as it was passed in: pairs of items i < j
+// with Key(i) > Key(j).  Items with equal keys don't count, whatever Less
+// says about them.  The count describes only the order data had on the way
+// in, so if an earlier pass already partly sorted data, the inversions it
+// removed aren't counted.
+//
+// Radix sorting can't count inversions, so this merge sorts the keys
+// alongside their original positions, counting as it goes, then moves
+// data into place.  That's O(n log n) and takes about 32 bytes of extra
+// memory per item.
+func ByInt64Inversions(data Int64Interface) int64 {
+	l := data.Len()
+	keys := make([]uint64, l)
+	for i := range keys {
+		keys[i] = int64Key(data.Key(i))
+	}
+	perm, inversions := mergeCount(keys)
+	applyPerm(data, perm)
+	sortEqualKeyRuns(data, keys)
+
+	// check results!
+	checkInt64(data, 0, l)
+	return inversions
+}
+
+// mergeCount stably sorts keys with a bottom-up merge sort, and returns perm
+// such that the key now at i was originally at perm[i], along with the
+// number of inversions it fixed.
+func mergeCount(keys []uint64) (perm []int, inversions int64) {
+	l := len(keys)
+	perm = make([]int, l)
+	for i := range perm {
+		perm[i] = i
+	}
+	src, dst := keys, make([]uint64, l)
+	permSrc, permDst := perm, make([]int, l)
+	for width := 1; width < l; width *= 2 {
+		for a := 0; a < l; a += 2 * width {
+			m, b := a+width, a+2*width
+			if m > l {
+				m = l
+			}
+			if b > l {
+				b = l
+			}
+			i, j := a, m
+			for k := a; k < b; k++ {
+				if j == b || (i < m && src[i] <= src[j]) {
+					dst[k], permDst[k] = src[i], permSrc[i]
+					i++
+				} else {
+					// src[j] jumps ahead of everything left in src[i:m]
+					inversions += int64(m - i)
+					dst[k], permDst[k] = src[j], permSrc[j]
+					j++
+				}
+			}
+		}
+		src, dst = dst, src
+		permSrc, permDst = permDst, permSrc
+	}
+	if l > 0 && &src[0] != &keys[0] {
+		copy(keys, src)
+	}
+	return permSrc, inversions
+}
@@ -0,0 +1,45 @@
+// Copyright 2015 Randall Farmer. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sorts_test
+
+import (
+	"math/rand"
+	"testing"
+
+	. "github.com/twotwotwo/sorts"
+	. "github.com/twotwotwo/sorts/sortutil"
+)
+
+func TestByInt64Inversions(t *testing.T) {
+	for _, size := range []int{0, 1, 2, 10, 1000, 1025} {
+		data := make([]int64, size)
+		for i := range data {
+			data[i] = int64(rand.Intn(200)) - 100
+		}
+		want := int64(0)
+		for i := range data {
+			for j := i + 1; j < len(data); j++ {
+				if data[i] > data[j] {
+					want++
+				}
+			}
+		}
+		got := ByInt64Inversions(Int64Slice(data))
+		if got != want {
+			t.Errorf("size %d: got %d inversions, want %d", size, got, want)
+		}
+		if !Int64sAreSorted(data) {
+			t.Errorf("size %d: not sorted", size)
+		}
+	}
+
+	reversed := Int64Slice{5, 4, 3, 2, 1}
+	if got := ByInt64Inversions(reversed); got != 10 {
+		t.Errorf("reversed input: got %d inversions, want 10", got)
+	}
+	if got := ByInt64Inversions(reversed); got != 0 {
+		t.Errorf("sorted input: got %d inversions, want 0", got)
+	}
+}
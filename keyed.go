@@ -0,0 +1,147 @@
+// Copyright 2015 Randall Farmer. All rights reserved.
+
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+package sorts
+
+import "sort"
+
+// maxKeyedSort is the most items qSortUint64/qSortInt64 sort by key; it
+// matches the default qSortCutoff, so small buckets always qualify.
+// Ranges up to maxSmallKeyedSort, the most common kind, get a smaller
+// buffer that's cheaper to set up.
+const (
+	maxKeyedSort      = 1 << 7
+	maxSmallKeyedSort = 16
+)
+
+// qSortUint64 sorts data[a:b] like qSort, but compares keys, read once
+// each into a buffer on the stack, instead of calling Less, which for
+// many types (floats, say) calls Key twice.  Less is only used to order
+// items with equal keys.  Ranges bigger than maxKeyedSort just use qSort.
+func qSortUint64(data Uint64Interface, a, b int) {
+	switch {
+	case b-a <= maxSmallKeyedSort:
+		var buf [maxSmallKeyedSort]uint64
+		sortKeyed(data, uint64Keys(data, buf[:b-a], a), a)
+	case b-a <= maxKeyedSort:
+		qSortUint64Large(data, a, b)
+	default:
+		qSort(data, a, b)
+	}
+}
+
+// qSortUint64Large is qSortUint64 with the larger buffer, kept in its own
+// function so qSortUint64's stack frame stays small.
+func qSortUint64Large(data Uint64Interface, a, b int) {
+	var buf [maxKeyedSort]uint64
+	sortKeyed(data, uint64Keys(data, buf[:b-a], a), a)
+}
+
+// uint64Keys fills keys with the keys of data[a:a+len(keys)].
+func uint64Keys(data Uint64Interface, keys []uint64, a int) []uint64 {
+	for i := range keys {
+		keys[i] = data.Key(a + i)
+	}
+	return keys
+}
+
+// qSortInt64 is qSortUint64 for int64 keys.
+func qSortInt64(data Int64Interface, a, b int) {
+	switch {
+	case b-a <= maxSmallKeyedSort:
+		var buf [maxSmallKeyedSort]uint64
+		sortKeyed(data, int64Keys(data, buf[:b-a], a), a)
+	case b-a <= maxKeyedSort:
+		qSortInt64Large(data, a, b)
+	default:
+		qSort(data, a, b)
+	}
+}
+
+// qSortInt64Large is qSortUint64Large for int64 keys.
+func qSortInt64Large(data Int64Interface, a, b int) {
+	var buf [maxKeyedSort]uint64
+	sortKeyed(data, int64Keys(data, buf[:b-a], a), a)
+}
+
+// int64Keys is uint64Keys for int64 keys.
+func int64Keys(data Int64Interface, keys []uint64, a int) []uint64 {
+	for i := range keys {
+		keys[i] = int64Key(data.Key(a + i))
+	}
+	return keys
+}
+
+// sortKeyed sorts data[a:a+len(keys)], where keys[i] is the key of
+// data[a+i], then uses Less to sort runs of equal keys.
+func sortKeyed(data sort.Interface, keys []uint64, a int) {
+	keyQuicksort(data, keys, a, 0, len(keys))
+	start := 0
+	for i := 1; i <= len(keys); i++ {
+		if i == len(keys) || keys[i] != keys[start] {
+			if i-start > 1 {
+				qSortEqualKeyRange(data, a+start, a+i)
+			}
+			start = i
+		}
+	}
+}
+
+// keySwap swaps keys i and j along with the items they belong to.
+func keySwap(data sort.Interface, keys []uint64, a, i, j int) {
+	keys[i], keys[j] = keys[j], keys[i]
+	data.Swap(a+i, a+j)
+}
+
+// keyQuicksort sorts keys[lo:hi], and data along with it, with a plain
+// median-of-three quicksort that insertion sorts small ranges.
+func keyQuicksort(data sort.Interface, keys []uint64, a, lo, hi int) {
+	for hi-lo > 12 {
+		// move the median of the first, middle, and last keys to lo
+		m := lo + (hi-lo)/2
+		if keys[m] < keys[lo] {
+			keySwap(data, keys, a, m, lo)
+		}
+		if keys[hi-1] < keys[m] {
+			keySwap(data, keys, a, hi-1, m)
+			if keys[m] < keys[lo] {
+				keySwap(data, keys, a, m, lo)
+			}
+		}
+		keySwap(data, keys, a, lo, m)
+
+		p := keys[lo]
+		i, j := lo+1, hi-1
+		for {
+			for i <= j && keys[i] < p {
+				i++
+			}
+			for i <= j && keys[j] > p {
+				j--
+			}
+			if i >= j {
+				break
+			}
+			keySwap(data, keys, a, i, j)
+			i++
+			j--
+		}
+		keySwap(data, keys, a, lo, j)
+
+		// recurse into the smaller side, loop on the larger
+		if j-lo < hi-j-1 {
+			keyQuicksort(data, keys, a, lo, j)
+			lo = j + 1
+		} else {
+			keyQuicksort(data, keys, a, j+1, hi)
+			hi = j
+		}
+	}
+	for i := lo + 1; i < hi; i++ {
+		for j := i; j > lo && keys[j] < keys[j-1]; j-- {
+			keySwap(data, keys, a, j, j-1)
+		}
+	}
+}
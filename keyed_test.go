@@ -0,0 +1,75 @@
+// Copyright 2015 Randall Farmer. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sorts_test
+
+import (
+	"math/rand"
+	"testing"
+
+	. "github.com/twotwotwo/sorts"
+	. "github.com/twotwotwo/sorts/sortutil"
+)
+
+// pairs sorts by a coarse key, the high bits of each pair's first
+// element, then by Less on both elements.
+type pairs [][2]int
+
+func (p pairs) Len() int { return len(p) }
+func (p pairs) Less(i, j int) bool {
+	if p[i][0] != p[j][0] {
+		return p[i][0] < p[j][0]
+	}
+	return p[i][1] < p[j][1]
+}
+func (p pairs) Swap(i, j int)     { p[i], p[j] = p[j], p[i] }
+func (p pairs) Key(i int) int64   { return int64(p[i][0]) }
+func (p pairs) KeyU(i int) uint64 { return uint64(p[i][0]) }
+
+type upairs struct{ pairs }
+
+func (p upairs) Key(i int) uint64 { return p.KeyU(i) }
+
+func TestKeyedSmallSorts(t *testing.T) {
+	for _, size := range []int{2, 13, 16, 17, 100, 127, 128, 129, 1000} {
+		p := make(pairs, size)
+		for i := range p {
+			p[i] = [2]int{rand.Intn(size/4+1) - size/8, rand.Intn(3)}
+		}
+		forceRadix(func() { ByInt64(p) })
+		if !p.isSorted() {
+			t.Errorf("ByInt64 size %d: not sorted", size)
+		}
+		for i := range p {
+			p[i][0] += size
+		}
+		rand.Shuffle(len(p), p.Swap)
+		ByUint64(upairs{p})
+		if !p.isSorted() {
+			t.Errorf("ByUint64 size %d: not sorted", size)
+		}
+	}
+}
+
+func (p pairs) isSorted() bool {
+	for i := 1; i < len(p); i++ {
+		if p.Less(i, i-1) {
+			return false
+		}
+	}
+	return true
+}
+
+func BenchmarkSortFloat64s1K(b *testing.B) {
+	b.StopTimer()
+	data := make([]float64, 1<<10)
+	for i := 0; i < b.N; i++ {
+		for i := range data {
+			data[i] = rand.Float64()
+		}
+		b.StartTimer()
+		Float64s(data)
+		b.StopTimer()
+	}
+}
@@ -0,0 +1,109 @@
+// Copyright 2015 Randall Farmer. All rights reserved.
+
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+package sorts
+
+import "sort"
+
+// ByKeyFunc sorts n items by the uint64 key that key returns for each,
+// moving them with swap, like sort.Slice without the slice.  key may be
+// called several times per item, so it should be cheap.  Items with equal
+// keys end up in no particular order.
+func ByKeyFunc(n int, swap func(i, j int), key func(i int) uint64) {
+	ByUint64(keyFuncs{n, swap, key})
+}
+
+// ByStringKeyFunc is ByKeyFunc for string keys.
+func ByStringKeyFunc(n int, swap func(i, j int), key func(i int) string) {
+	ByString(stringKeyFuncs{n, swap, key})
+}
+
+// ByInt64Field is ByKeyFunc for signed keys, like a struct's int64 field
+// holding an amount in cents, so negative keys sort before positive ones.
+func ByInt64Field(n int, swap func(i, j int), key func(i int) int64) {
+	ByInt64(int64KeyFuncs{n, swap, key})
+}
+
+// ByKeyThenLess sorts data by the uint64 key key returns for each item,
+// calling data.Less only to order items with equal keys, like an
+// index.Index without the Index.  key is called once per item, and the
+// keys are kept in a slice while sorting, 8 bytes per item.
+func ByKeyThenLess(data sort.Interface, key func(i int) uint64) {
+	k := keysThenLess{make([]uint64, data.Len()), data}
+	for i := range k.keys {
+		k.keys[i] = key(i)
+	}
+	ByUint64(k)
+}
+
+// byBulkKeys sorts data by the keys KeysInto gives, kept in a slice
+// alongside data.  The check afterwards uses data's own Less, since
+// checking the copied keys couldn't catch KeysInto disagreeing with it.
+func byBulkKeys(data BulkKeyInterface) {
+	k := keysThenLess{make([]uint64, data.Len()), data}
+	data.KeysInto(k.keys)
+	byUint64Range(k, 0, len(k.keys), parallelSort, &Options{noCheck: true})
+	checkUint64(data, 0, len(k.keys))
+}
+
+// keysThenLess sorts data by keys, then data.Less, swapping both.
+type keysThenLess struct {
+	keys []uint64
+	data sort.Interface
+}
+
+func (k keysThenLess) Len() int { return len(k.keys) }
+func (k keysThenLess) Less(i, j int) bool {
+	return k.keys[i] < k.keys[j] || k.keys[i] == k.keys[j] && k.data.Less(i, j)
+}
+func (k keysThenLess) Swap(i, j int) {
+	k.keys[i], k.keys[j] = k.keys[j], k.keys[i]
+	k.data.Swap(i, j)
+}
+func (k keysThenLess) Key(i int) uint64 { return k.keys[i] }
+
+// ByKeyOnly sorts data by its uint64 key, ordering and checking items by
+// their keys alone.  Items with equal keys end up in no particular order.
+func ByKeyOnly(data KeyOnlyInterface) {
+	ByUint64(keyOnly{data})
+}
+
+// keyOnly adds a Less based on Key to a KeyOnlyInterface.
+type keyOnly struct{ KeyOnlyInterface }
+
+func (k keyOnly) Less(i, j int) bool { return k.Key(i) < k.Key(j) }
+
+type keyFuncs struct {
+	n    int
+	swap func(i, j int)
+	key  func(i int) uint64
+}
+
+func (k keyFuncs) Len() int           { return k.n }
+func (k keyFuncs) Less(i, j int) bool { return k.key(i) < k.key(j) }
+func (k keyFuncs) Swap(i, j int)      { k.swap(i, j) }
+func (k keyFuncs) Key(i int) uint64   { return k.key(i) }
+
+type stringKeyFuncs struct {
+	n    int
+	swap func(i, j int)
+	key  func(i int) string
+}
+
+func (k stringKeyFuncs) Len() int           { return k.n }
+func (k stringKeyFuncs) Less(i, j int) bool { return k.key(i) < k.key(j) }
+func (k stringKeyFuncs) Swap(i, j int)      { k.swap(i, j) }
+func (k stringKeyFuncs) Key(i int) string   { return k.key(i) }
+
+type int64KeyFuncs struct {
+	n    int
+	swap func(i, j int)
+	key  func(i int) int64
+}
+
+func (k int64KeyFuncs) Len() int           { return k.n }
+func (k int64KeyFuncs) Less(i, j int) bool { return k.key(i) < k.key(j) }
+func (k int64KeyFuncs) Swap(i, j int)      { k.swap(i, j) }
+func (k int64KeyFuncs) Key(i int) int64    { return k.key(i) }
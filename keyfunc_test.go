@@ -0,0 +1,176 @@
+// Copyright 2015 Randall Farmer. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sorts_test
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+	"strconv"
+	"testing"
+
+	. "github.com/twotwotwo/sorts"
+	. "github.com/twotwotwo/sorts/sortutil"
+)
+
+type employee struct {
+	name string
+	id   uint64
+}
+
+func TestByKeyFunc(t *testing.T) {
+	staff := make([]employee, 10000)
+	for i := range staff {
+		id := uint64(rand.Intn(1e6))
+		staff[i] = employee{strconv.FormatUint(id, 36), id}
+	}
+	swap := func(i, j int) { staff[i], staff[j] = staff[j], staff[i] }
+
+	ByKeyFunc(len(staff), swap, func(i int) uint64 { return staff[i].id })
+	if !sort.SliceIsSorted(staff, func(i, j int) bool { return staff[i].id < staff[j].id }) {
+		t.Errorf("ByKeyFunc didn't sort by id")
+	}
+
+	ByStringKeyFunc(len(staff), swap, func(i int) string { return staff[i].name })
+	if !sort.SliceIsSorted(staff, func(i, j int) bool { return staff[i].name < staff[j].name }) {
+		t.Errorf("ByStringKeyFunc didn't sort by name")
+	}
+}
+
+func TestByInt64Field(t *testing.T) {
+	type transaction struct {
+		id    int
+		cents int64
+	}
+	txns := make([]transaction, 10000)
+	for i := range txns {
+		txns[i] = transaction{i, rand.Int63n(2e6) - 1e6}
+	}
+	ByInt64Field(len(txns), func(i, j int) { txns[i], txns[j] = txns[j], txns[i] }, func(i int) int64 { return txns[i].cents })
+	if !sort.SliceIsSorted(txns, func(i, j int) bool { return txns[i].cents < txns[j].cents }) {
+		t.Errorf("ByInt64Field didn't sort by amount")
+	}
+	if txns[0].cents >= 0 {
+		t.Errorf("negative amounts didn't sort first")
+	}
+}
+
+// bitsOnly sorts float64s by their bits, which is enough for
+// non-negative numbers, including NaNs with the sign bit clear.
+type bitsOnly []float64
+
+func (b bitsOnly) Len() int         { return len(b) }
+func (b bitsOnly) Swap(i, j int)    { b[i], b[j] = b[j], b[i] }
+func (b bitsOnly) Key(i int) uint64 { return math.Float64bits(b[i]) }
+
+func TestByKeyOnly(t *testing.T) {
+	a := make(bitsOnly, 10000)
+	for i := range a {
+		a[i] = rand.Float64() * 1e6
+		if i%100 == 0 {
+			a[i] = math.NaN()
+		}
+	}
+	ByKeyOnly(a)
+	for i := 1; i < len(a); i++ {
+		if a.Key(i) < a.Key(i-1) {
+			t.Fatalf("item %d (%v) sorted after %v", i, a[i], a[i-1])
+		}
+	}
+	if !math.IsNaN(a[len(a)-1]) {
+		t.Errorf("NaNs didn't sort last")
+	}
+}
+
+// byName sorts employees by name, counting comparisons of unequal ids.
+type byName struct {
+	staff  []employee
+	untied *int
+}
+
+func (b byName) Len() int { return len(b.staff) }
+func (b byName) Less(i, j int) bool {
+	if b.staff[i].id != b.staff[j].id {
+		*b.untied++
+	}
+	return b.staff[i].name < b.staff[j].name
+}
+func (b byName) Swap(i, j int) { b.staff[i], b.staff[j] = b.staff[j], b.staff[i] }
+
+func TestByKeyThenLess(t *testing.T) {
+	staff := make([]employee, 10000)
+	for i := range staff {
+		staff[i] = employee{strconv.Itoa(rand.Int()), uint64(rand.Intn(100))}
+	}
+	calls, untied := 0, 0
+	ByKeyThenLess(byName{staff, &untied}, func(i int) uint64 {
+		calls++
+		return staff[i].id
+	})
+	if calls != len(staff) {
+		t.Errorf("key called %d times for %d items", calls, len(staff))
+	}
+	if untied != 0 {
+		t.Errorf("Less called %d times for items with different keys", untied)
+	}
+	if !sort.SliceIsSorted(staff, func(i, j int) bool {
+		return staff[i].id < staff[j].id || staff[i].id == staff[j].id && staff[i].name < staff[j].name
+	}) {
+		t.Errorf("not sorted by id, then name")
+	}
+}
+
+// column is a BulkKeyInterface counting its Key and KeysInto calls.
+type column struct {
+	Uint64Slice
+	keyCalls, bulkCalls *int
+}
+
+func (c column) Key(i int) uint64 {
+	*c.keyCalls++
+	return c.Uint64Slice[i]
+}
+
+func (c column) KeysInto(dst []uint64) {
+	*c.bulkCalls++
+	copy(dst, c.Uint64Slice)
+}
+
+func TestBulkKeys(t *testing.T) {
+	a := make([]uint64, 10000)
+	for i := range a {
+		a[i] = uint64(rand.Int63())
+	}
+	keyCalls, bulkCalls := 0, 0
+	ByUint64(column{a, &keyCalls, &bulkCalls})
+	if !Uint64sAreSorted(a) {
+		t.Errorf("not sorted")
+	}
+	if keyCalls != 0 || bulkCalls != 1 {
+		t.Errorf("%d Key calls and %d KeysInto calls, want 0 and 1", keyCalls, bulkCalls)
+	}
+}
+
+// backwardColumn's KeysInto gives keys in the opposite order to its Less.
+type backwardColumn struct{ Uint64Slice }
+
+func (c backwardColumn) KeysInto(dst []uint64) {
+	for i, v := range c.Uint64Slice {
+		dst[i] = ^v
+	}
+}
+
+func TestBulkKeysCheck(t *testing.T) {
+	a := make([]uint64, 10000)
+	for i := range a {
+		a[i] = uint64(rand.Int63())
+	}
+	defer func() {
+		if recover() == nil {
+			t.Errorf("KeysInto disagreeing with Less didn't panic")
+		}
+	}()
+	ByUint64(backwardColumn{a})
+}
@@ -0,0 +1,37 @@
+// Copyright 2015 Randall Farmer. All rights reserved.
+
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+// Package kv sorts parallel slices of keys and values, as storage code
+// building sorted files often needs to.
+package kv
+
+import "github.com/twotwotwo/sorts"
+
+// pairs sorts keys, moving vals along with them.
+type pairs struct {
+	keys []uint64
+	vals []int
+}
+
+func (p pairs) Len() int { return len(p.keys) }
+func (p pairs) Less(i, j int) bool {
+	return p.keys[i] < p.keys[j] || (p.keys[i] == p.keys[j] && p.vals[i] < p.vals[j])
+}
+func (p pairs) Swap(i, j int) {
+	p.keys[i], p.keys[j] = p.keys[j], p.keys[i]
+	p.vals[i], p.vals[j] = p.vals[j], p.vals[i]
+}
+func (p pairs) Key(i int) uint64 { return p.keys[i] }
+
+// SortPairs sorts keys in increasing order, making the same moves in vals
+// so each value stays with its key.  Pairs with equal keys are ordered by
+// value, so, say, offsets of duplicate keys come out in file order.  It
+// panics if keys and vals differ in length.
+func SortPairs(keys []uint64, vals []int) {
+	if len(keys) != len(vals) {
+		panic("kv: SortPairs needs keys and vals of the same length")
+	}
+	sorts.ByUint64(pairs{keys, vals})
+}
@@ -0,0 +1,48 @@
+// Copyright 2015 Randall Farmer. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package kv_test
+
+import (
+	"math/rand"
+	"testing"
+
+	. "github.com/twotwotwo/sorts/kv"
+)
+
+func TestSortPairs(t *testing.T) {
+	for _, n := range []int{0, 1, 100, 100000} {
+		keys := make([]uint64, n)
+		vals := make([]int, n)
+		want := map[uint64]int{}
+		for i := range keys {
+			keys[i] = uint64(rand.Intn(n/2 + 1))
+			vals[i] = i
+			want[keys[i]] += i
+		}
+		SortPairs(keys, vals)
+		for i := 1; i < n; i++ {
+			if keys[i] < keys[i-1] || (keys[i] == keys[i-1] && vals[i] < vals[i-1]) {
+				t.Fatalf("n=%d: pairs %d and %d out of order", n, i-1, i)
+			}
+		}
+		for i, k := range keys {
+			want[k] -= vals[i]
+		}
+		for k, v := range want {
+			if v != 0 {
+				t.Fatalf("n=%d: values for key %d got mixed up", n, k)
+			}
+		}
+	}
+}
+
+func TestSortPairsLengths(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("mismatched lengths didn't panic")
+		}
+	}()
+	SortPairs(make([]uint64, 2), make([]int, 1))
+}
@@ -0,0 +1,194 @@
+// Copyright 2015 Randall Farmer. All rights reserved.
+
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+package sorts
+
+import (
+	"sort"
+	"sync"
+)
+
+// Options tunes a single sort, so goroutines sorting different kinds of
+// data can each use their own settings without touching package-level
+// ones, which can't safely change while sorts run.  A zero or negative
+// field means to use the package default.
+type Options struct {
+	// QSortCutoff is the size of the smallest range to radix sort;
+	// smaller ranges are sorted by comparison.  The default is 128.
+	QSortCutoff int
+	// MaxProcs, like the package's MaxProcs, limits how many goroutines
+	// the sort uses; 1 makes it serial.
+	MaxProcs int
+	// MinParallel is the size of the smallest collection to sort in
+	// parallel.  The default is 10000.
+	MinParallel int
+	// MinOffload is the size of the smallest range a parallel sort hands
+	// off to another goroutine.  The default is 127.
+	MinOffload int
+	// ProgressFunc, if set, is called as a radix sort finishes putting
+	// items in their final places, with how many are done out of the
+	// total.  Calls are coarse (at most about a thousand per sort) and
+	// made one at a time, each with a larger done than the last, but they
+	// can come from any of the sort's goroutines.  The last call has done
+	// equal to total.  It isn't called for sorts too small to radix sort,
+	// or for data found already sorted.
+	ProgressFunc func(done, total int)
+	// Scheduler, if set, runs a parallel sort's worker goroutines in
+	// place of the go statement, so they can come out of a pool or count
+	// against a limit the program already has.  MaxProcs still caps how
+	// many workers are submitted, and a limit below MaxProcs is fine: the
+	// sort goes ahead with however many workers the Scheduler lets run.
+	Scheduler Scheduler
+	// PoolTables, if set, makes radix sorts take the 4KB of tables each
+	// level of recursion needs from a sync.Pool instead of the stack.
+	// Deep string sorts can otherwise grow a goroutine's stack by over
+	// 100KB.  Its speed is about the same.
+	PoolTables bool
+
+	// maxRadixDepth, if set, replaces the package constant; see ByBytesFixed.
+	maxRadixDepth int
+	// noCheck skips the check after a radix sort, for callers that make
+	// their own pass over the data anyway; see ByUint64Distinct.
+	noCheck bool
+}
+
+// qSortCutoff returns o's QSortCutoff, or the package's if o is nil or
+// doesn't set one.
+func (o *Options) qSortCutoff() int {
+	if o != nil && o.QSortCutoff > 0 {
+		return o.QSortCutoff
+	}
+	return qSortCutoff
+}
+
+// maxProcs returns o's MaxProcs, or the package's.
+func (o *Options) maxProcs() int {
+	if o != nil && o.MaxProcs > 0 {
+		return o.MaxProcs
+	}
+	return MaxProcs
+}
+
+// minParallel is qSortCutoff for MinParallel.  The package default is
+// kept at 1 or more; parallel sorts of nothing aren't worth starting.
+func (o *Options) minParallel() int {
+	if o != nil && o.MinParallel > 0 {
+		return o.MinParallel
+	}
+	if minParallel < 1 {
+		return 1
+	}
+	return minParallel
+}
+
+// minOffload is qSortCutoff for MinOffload.  The package default is kept
+// at 1 or more, so empty ranges are never handed off and quickSortWorker
+// always stops pivoting.
+func (o *Options) minOffload() int {
+	if o != nil && o.MinOffload > 0 {
+		return o.MinOffload
+	}
+	if minOffload < 1 {
+		return 1
+	}
+	return minOffload
+}
+
+// radixDepth is how many levels string and []byte radix sorts may recurse
+// before quicksorting what's left.
+func (o *Options) radixDepth() int {
+	if o != nil && o.maxRadixDepth > 0 {
+		return o.maxRadixDepth
+	}
+	return maxRadixDepth
+}
+
+// check says whether to check the data once it's radix sorted.
+func (o *Options) check() bool { return o == nil || !o.noCheck }
+
+// scheduler returns o's Scheduler, or nil to use the go statement.
+func (o *Options) scheduler() Scheduler {
+	if o == nil {
+		return nil
+	}
+	return o.Scheduler
+}
+
+// poolTables says whether sorts should use tablePool.
+func (o *Options) poolTables() bool { return o != nil && o.PoolTables }
+
+// wrap adds progress reporting to sorter, if o asks for it, for a sort
+// starting with task t.
+func (o *Options) wrap(sorter sortFunc, t task) sortFunc {
+	if o == nil || o.ProgressFunc == nil {
+		return sorter
+	}
+	p := &progress{f: o.ProgressFunc, total: t.end - t.pos}
+	return p.wrap(sorter)
+}
+
+// progress tracks how many items a sort has finished with.  Each item is
+// counted by the task that finally places it: whatever part of its range
+// a task doesn't hand off as subtasks is done when it returns.
+type progress struct {
+	mu                    sync.Mutex
+	f                     func(done, total int)
+	done, reported, total int
+}
+
+func (p *progress) wrap(sorter sortFunc) sortFunc {
+	return func(data sort.Interface, t task, sortRange func(task)) {
+		handedOff := 0
+		sorter(data, t, func(sub task) {
+			handedOff += sub.end - sub.pos
+			sortRange(sub)
+		})
+		p.add(t.end - t.pos - handedOff)
+	}
+}
+
+// add counts n more items done, calling f if enough have piled up since
+// the last call, or if the sort's finished.
+func (p *progress) add(n int) {
+	if n == 0 {
+		return
+	}
+	p.mu.Lock()
+	p.done += n
+	if p.done == p.total || p.done-p.reported >= p.total>>10 {
+		p.reported = p.done
+		p.f(p.done, p.total)
+	}
+	p.mu.Unlock()
+}
+
+// ByUint64With is ByUint64 using the settings in opts.
+func ByUint64With(data Uint64Interface, opts Options) {
+	byUint64Range(data, 0, data.Len(), parallelSort, &opts)
+}
+
+// ByInt64With is ByInt64 using the settings in opts.
+func ByInt64With(data Int64Interface, opts Options) {
+	byInt64Range(data, 0, data.Len(), parallelSort, &opts)
+}
+
+// ByStringWith is ByString using the settings in opts.
+func ByStringWith(data StringInterface, opts Options) {
+	byStringRange(data, 0, data.Len(), parallelSort, &opts)
+}
+
+// ByBytesWith is ByBytes using the settings in opts.
+func ByBytesWith(data BytesInterface, opts Options) {
+	byBytesRange(data, 0, data.Len(), parallelSort, &opts)
+}
+
+// ByUint64Workers is ByUint64 using at most workers goroutines, or
+// MaxProcs if workers is 0 or less, without touching the package's
+// MaxProcs, so sorts for different callers can have different limits.
+// It's short for ByUint64With(data, Options{MaxProcs: workers}); use
+// Options for other key types or settings.
+func ByUint64Workers(data Uint64Interface, workers int) {
+	ByUint64With(data, Options{MaxProcs: workers})
+}
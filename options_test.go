@@ -0,0 +1,317 @@
+// Copyright 2015 Randall Farmer. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sorts_test
+
+import (
+	"math/rand"
+	"runtime"
+	"sort"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	. "github.com/twotwotwo/sorts"
+	. "github.com/twotwotwo/sorts/sortutil"
+)
+
+// keyCounter counts Key calls; its Less doesn't call Key.
+type keyCounter struct {
+	Uint64Slice
+	keys *int64
+}
+
+func (k keyCounter) Key(i int) uint64 {
+	atomic.AddInt64(k.keys, 1)
+	return k.Uint64Slice[i]
+}
+
+func TestByUint64With(t *testing.T) {
+	n := 100000
+	if testing.Short() {
+		n /= 10
+	}
+	var wg sync.WaitGroup
+	for _, opts := range []Options{{}, {QSortCutoff: 1, MinOffload: 1}, {QSortCutoff: 1 << 30}, {MinOffload: 1 << 30}} {
+		wg.Add(1)
+		go func(opts Options) {
+			defer wg.Done()
+			a := make([]uint64, n)
+			for i := range a {
+				a[i] = uint64(rand.Int63())
+			}
+			keys := int64(0)
+			ByUint64With(keyCounter{a, &keys}, opts)
+			if !Uint64sAreSorted(a) {
+				t.Errorf("%+v: not sorted", opts)
+			}
+			if opts.QSortCutoff > n && keys != 0 {
+				t.Errorf("%+v: radix sorted, calling Key %d times", opts, keys)
+			}
+			if opts.QSortCutoff <= n && keys == 0 {
+				t.Errorf("%+v: didn't radix sort", opts)
+			}
+		}(opts)
+	}
+	wg.Wait()
+
+	s := make([]string, n)
+	for i := range s {
+		s[i] = string(rune('a' + rand.Intn(26)))
+	}
+	ByStringWith(StringSlice(s), Options{QSortCutoff: 2})
+	if !sort.StringsAreSorted(s) {
+		t.Errorf("strings not sorted")
+	}
+	ints := make([]int, n)
+	for i := range ints {
+		ints[i] = rand.Int() - rand.Int()
+	}
+	ByInt64With(IntSlice(ints), Options{QSortCutoff: 16})
+	if !sort.IntsAreSorted(ints) {
+		t.Errorf("ints not sorted")
+	}
+	b := make([][]byte, n)
+	for i := range b {
+		b[i] = []byte(s[rand.Intn(n)])
+	}
+	ByBytesWith(BytesSlice(b), Options{MinOffload: 1})
+	if !BytesAreSorted(b) {
+		t.Errorf("bytes not sorted")
+	}
+}
+
+func TestProgressFunc(t *testing.T) {
+	n := 100000
+	a := make([]uint64, n)
+	s := make([]string, n)
+	for i := range a {
+		a[i] = uint64(rand.Int63())
+		s[i] = strconv.Itoa(rand.Int())
+	}
+	check := func(name string, sort func(Options)) {
+		calls, last := 0, 0
+		sort(Options{ProgressFunc: func(done, total int) {
+			calls++
+			if total != n || done <= last || done > total {
+				t.Errorf("%s: progress went from %d to %d of %d", name, last, done, total)
+			}
+			last = done
+		}})
+		if last != n {
+			t.Errorf("%s: last progress report was %d of %d", name, last, n)
+		}
+		if calls < 10 || calls > 1100 {
+			t.Errorf("%s: %d progress reports", name, calls)
+		}
+	}
+	check("uint64s", func(o Options) { ByUint64With(Uint64Slice(a), o) })
+	check("strings", func(o Options) { ByStringWith(StringSlice(s), o) })
+}
+
+// limitScheduler is a Scheduler running at most cap(slots) functions at
+// once, counting how many it was given.
+type limitScheduler struct {
+	slots     chan struct{}
+	wg        sync.WaitGroup
+	submitted int
+}
+
+func (s *limitScheduler) Submit(f func()) {
+	s.slots <- struct{}{}
+	s.submitted++
+	s.wg.Add(1)
+	go func() {
+		defer func() { <-s.slots; s.wg.Done() }()
+		f()
+	}()
+}
+
+func (s *limitScheduler) Wait() { s.wg.Wait() }
+
+func TestScheduler(t *testing.T) {
+	defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(4))
+	defer func(old int) { MaxProcs = old }(MaxProcs)
+	MaxProcs = 2
+	a := make([]uint64, 100000)
+	for i := range a {
+		a[i] = uint64(rand.Int63())
+	}
+	s := &limitScheduler{slots: make(chan struct{}, 2)}
+	ByUint64With(Uint64Slice(a), Options{Scheduler: s})
+	if !Uint64sAreSorted(a) {
+		t.Errorf("not sorted")
+	}
+	if s.submitted != MaxProcs {
+		t.Errorf("%d workers submitted, want %d", s.submitted, MaxProcs)
+	}
+	if len(s.slots) != 0 {
+		t.Errorf("%d workers still running after the sort", len(s.slots))
+	}
+}
+
+// TestSchedulerBelowMaxProcs has the Scheduler's limit below MaxProcs, so
+// some workers can't start until the sort's done with the others.
+func TestSchedulerBelowMaxProcs(t *testing.T) {
+	defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(8))
+	a := make([]uint64, 1000000)
+	for i := range a {
+		a[i] = uint64(rand.Int63())
+	}
+	s := &limitScheduler{slots: make(chan struct{}, 2)}
+	done := make(chan struct{})
+	go func() {
+		ByUint64With(Uint64Slice(a), Options{MaxProcs: 4, Scheduler: s})
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Minute):
+		t.Fatal("sort deadlocked")
+	}
+	if !Uint64sAreSorted(a) {
+		t.Errorf("not sorted")
+	}
+	if s.submitted != 4 {
+		t.Errorf("%d workers submitted, want 4", s.submitted)
+	}
+	if len(s.slots) != 0 {
+		t.Errorf("%d workers still running after the sort", len(s.slots))
+	}
+}
+
+func TestPoolTables(t *testing.T) {
+	opts := Options{PoolTables: true, QSortCutoff: 2}
+	for _, n := range []int{0, 1, 1000, 100000} {
+		a := make([]int, n)
+		for i := range a {
+			a[i] = rand.Int() - rand.Int()
+		}
+		asBytes, asStrings, asUints := convertInts(a)
+		asUint64s := make([]uint64, len(asUints))
+		for i, v := range asUints {
+			asUint64s[i] = uint64(v)
+		}
+		ByInt64With(IntSlice(a), opts)
+		ByUint64With(Uint64Slice(asUint64s), opts)
+		ByStringWith(StringSlice(asStrings), opts)
+		ByBytesWith(BytesSlice(asBytes), opts)
+		if !sort.IntsAreSorted(a) || !Uint64sAreSorted(asUint64s) ||
+			!sort.StringsAreSorted(asStrings) || !BytesAreSorted(asBytes) {
+			t.Errorf("n=%d: not sorted", n)
+		}
+	}
+}
+
+func benchPoolTables(b *testing.B, opts Options) {
+	b.StopTimer()
+	s := make([]string, 1e5)
+	for i := 0; i < b.N; i++ {
+		for j := range s {
+			s[j] = strconv.Itoa(rand.Int())
+		}
+		b.StartTimer()
+		ByStringWith(StringSlice(s), opts)
+		b.StopTimer()
+	}
+}
+
+func BenchmarkStackTables(b *testing.B) { benchPoolTables(b, Options{}) }
+func BenchmarkPoolTables(b *testing.B)  { benchPoolTables(b, Options{PoolTables: true}) }
+
+func TestLimits(t *testing.T) {
+	defer SetMinParallel(SetMinParallel(10000))
+	defer SetMinOffload(SetMinOffload(127))
+	for _, c := range []struct {
+		opts                      *Options
+		parallel, offload         int
+		wantParallel, wantOffload int
+	}{
+		{nil, 10000, 127, 10000, 127},
+		{&Options{MinParallel: 5, MinOffload: 6}, 10000, 127, 5, 6},
+		{&Options{MinParallel: 1, MinOffload: 1}, 10000, 127, 1, 1},
+		{&Options{MinParallel: -1, MinOffload: -1}, 10000, 127, 10000, 127},
+		{&Options{}, 0, 0, 1, 1},
+		{nil, -5, -5, 1, 1},
+	} {
+		SetMinParallel(c.parallel)
+		SetMinOffload(c.offload)
+		if p, o := Limits(c.opts); p != c.wantParallel || o != c.wantOffload {
+			t.Errorf("%+v with package limits %d, %d: got %d, %d, want %d, %d",
+				c.opts, c.parallel, c.offload, p, o, c.wantParallel, c.wantOffload)
+		}
+	}
+
+	// and sorts still work at the edges
+	defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(4))
+	SetMinParallel(-1)
+	SetMinOffload(-1)
+	for _, opts := range []Options{{}, {MinParallel: 1, MinOffload: 1}, {MinParallel: -1, MinOffload: -1}} {
+		for _, n := range []int{0, 1, 2, 1000} {
+			a := make([]int, n)
+			for i := range a {
+				a[i] = rand.Int() - rand.Int()
+			}
+			s := make([]string, n)
+			for i := range s {
+				s[i] = strconv.Itoa(a[i] & 0xff)
+			}
+			ByInt64With(IntSlice(a), opts)
+			ByStringWith(StringSlice(s), opts)
+			if !sort.IntsAreSorted(a) || !sort.StringsAreSorted(s) {
+				t.Errorf("%+v, n=%d: not sorted", opts, n)
+			}
+		}
+	}
+}
+
+func TestOptionsMaxProcs(t *testing.T) {
+	defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(4))
+	var wg sync.WaitGroup
+	for procs := 1; procs <= 4; procs++ {
+		wg.Add(1)
+		go func(procs int) {
+			defer wg.Done()
+			a := make([]uint64, 100000)
+			for i := range a {
+				a[i] = uint64(rand.Int63())
+			}
+			s := &limitScheduler{slots: make(chan struct{}, 4)}
+			ByUint64With(Uint64Slice(a), Options{MaxProcs: procs, Scheduler: s})
+			if !Uint64sAreSorted(a) {
+				t.Errorf("MaxProcs %d: not sorted", procs)
+			}
+			want := procs
+			if procs == 1 {
+				want = 0 // serial
+			}
+			if s.submitted != want {
+				t.Errorf("MaxProcs %d: %d workers, want %d", procs, s.submitted, want)
+			}
+		}(procs)
+	}
+	wg.Wait()
+}
+
+func TestByUint64Workers(t *testing.T) {
+	defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(4))
+	var wg sync.WaitGroup
+	for _, workers := range []int{0, 1, 2, 8} {
+		wg.Add(1)
+		go func(workers int) {
+			defer wg.Done()
+			a := make([]uint64, 100000)
+			for i := range a {
+				a[i] = uint64(rand.Int63())
+			}
+			ByUint64Workers(Uint64Slice(a), workers)
+			if !Uint64sAreSorted(a) {
+				t.Errorf("%d workers: not sorted", workers)
+			}
+		}(workers)
+	}
+	wg.Wait()
+}
@@ -4,6 +4,7 @@ import (
 	"runtime"
 	"sort"
 	"sync"
+	"sync/atomic"
 )
 
 // helpers to coordinate parallel sorts
@@ -11,7 +12,10 @@ import (
 type sortFunc func(sort.Interface, task, func(task))
 
 // MaxProcs controls how many goroutines to start for large sorts. If 0,
-// GOMAXPROCS will be used; if 1, all sorts will be serial.
+// GOMAXPROCS will be used; if 1, all sorts will be serial.  Like the other
+// package-level settings, set it before sorting starts: changing it while
+// sorts run is a data race.  To vary it from sort to sort, use
+// Options.MaxProcs.
 var MaxProcs = 0
 
 // minParallel is the size of the smallest collection we will try to sort in
@@ -23,59 +27,184 @@ var minParallel = 10000
 var minOffload = 127
 
 // bufferRatio is how many sorting tasks to queue (buffer) up per
-// worker goroutine.
+// worker goroutine.  With more than one worker, the queue always has
+// room for at least one task, however small bufferRatio is.
 var bufferRatio float32 = 1
 
+// runner runs a radix sort of data starting with initialTask: parallelSort
+// or a Pool's run method.
+type runner func(data sort.Interface, sorter sortFunc, initialTask task)
+
 // parallelSort calls the sorters with an asyncSort function that will hand
-// the task off to another goroutine when possible.
+// the task off to another goroutine when possible.  It starts goroutines
+// for this sort only; a Pool keeps them around for the next.
 func parallelSort(data sort.Interface, sorter sortFunc, initialTask task) {
 	max := runtime.GOMAXPROCS(0)
-	if MaxProcs > 0 && MaxProcs < max {
-		max = MaxProcs
+	if procs := initialTask.opts.maxProcs(); procs > 0 && procs < max {
+		max = procs
 	}
-	l := data.Len()
-	if l < minParallel {
-		max = 1
+	l := initialTask.end - initialTask.pos
+	if l < initialTask.opts.minParallel() || max == 1 {
+		serialSort(data, sorter, initialTask)
+		return
 	}
 
+	p := newPool(max, initialTask.opts.scheduler())
+	p.run(data, sorter, initialTask)
+	p.Close()
+}
+
+// serialSort runs sorter on initialTask and all its subtasks in this
+// goroutine.
+func serialSort(data sort.Interface, sorter sortFunc, initialTask task) {
 	var syncSort func(t task)
 	syncSort = func(t task) {
 		sorter(data, t, syncSort)
 	}
-	if max == 1 {
-		syncSort(initialTask)
+	syncSort(initialTask)
+}
+
+// Pool is a set of worker goroutines that sorts can share, so a program
+// doing many sorts doesn't start and stop goroutines for each one.  Its
+// methods can be called from several goroutines at once; their work is
+// spread over the same workers.  Collections smaller than parallel sorts
+// normally need are sorted in the calling goroutine.
+type Pool struct {
+	work      chan func()
+	workers   sync.WaitGroup
+	sched     Scheduler
+	submitted chan struct{} // closed once every worker's been submitted
+	started   int32         // workers running, read atomically
+}
+
+// Scheduler runs a parallel sort's workers, for programs that want sorting
+// to count against their own limits on goroutines; see Options.Scheduler.
+type Scheduler interface {
+	// Submit runs f, which returns when the sort is done with it, in
+	// some goroutine.  It may block while the Scheduler is at its limit,
+	// even past the end of the sort: workers are submitted from a
+	// goroutine of the sort's own, and until one starts, the sort runs
+	// in the goroutine that called it.  That goroutine mustn't be one
+	// counted against the limit, or the sort can deadlock.
+	Submit(f func())
+	// Wait returns once every f passed to Submit has returned.  A sort
+	// calls it once, after all its Submits; a Scheduler shouldn't be
+	// used by more than one sort at a time.
+	Wait()
+}
+
+// NewPool starts a Pool with the given number of workers; if workers is
+// 0 or less, it uses GOMAXPROCS.  MaxProcs doesn't apply to Pools.
+func NewPool(workers int) *Pool {
+	return newPool(workers, nil)
+}
+
+// newPool is NewPool, starting the workers through s if it isn't nil.
+func newPool(workers int, s Scheduler) *Pool {
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	// buffer up one extra task to keep each cpu busy
+	queueLen := int(float32(workers) * bufferRatio)
+	if queueLen < 1 && workers > 1 {
+		// unbuffered, a worker would have to be waiting right when a
+		// task was handed off, so most would run synchronously
+		queueLen = 1
+	}
+	p := &Pool{work: make(chan func(), queueLen), sched: s}
+	worker := func() {
+		for f := range p.work {
+			f()
+		}
+	}
+	if s != nil {
+		// a Scheduler at its limit can block Submit until the sort's
+		// over, so submit from elsewhere; tasks aren't queued until a
+		// worker's running to take them
+		p.submitted = make(chan struct{})
+		go func() {
+			for i := 0; i < workers; i++ {
+				s.Submit(func() {
+					atomic.AddInt32(&p.started, 1)
+					worker()
+				})
+			}
+			close(p.submitted)
+		}()
+		return p
+	}
+	p.started = int32(workers)
+	p.workers.Add(workers)
+	for i := 0; i < workers; i++ {
+		go func() {
+			worker()
+			p.workers.Done()
+		}()
+	}
+	return p
+}
+
+// Close stops the Pool's workers once they finish any queued work.  Call it
+// only after every sort using the Pool has returned; the Pool can't be used
+// afterwards.
+func (p *Pool) Close() {
+	close(p.work)
+	if p.sched != nil {
+		<-p.submitted
+		p.sched.Wait()
+		return
+	}
+	p.workers.Wait()
+}
+
+// ByUint64 is the package-level ByUint64, using the Pool's workers.
+func (p *Pool) ByUint64(data Uint64Interface) { byUint64Range(data, 0, data.Len(), p.run, nil) }
+
+// ByInt64 is the package-level ByInt64, using the Pool's workers.
+func (p *Pool) ByInt64(data Int64Interface) { byInt64Range(data, 0, data.Len(), p.run, nil) }
+
+// ByString is the package-level ByString, using the Pool's workers.
+func (p *Pool) ByString(data StringInterface) { byStringRange(data, 0, data.Len(), p.run, nil) }
+
+// ByBytes is the package-level ByBytes, using the Pool's workers.
+func (p *Pool) ByBytes(data BytesInterface) { byBytesRange(data, 0, data.Len(), p.run, nil) }
+
+// run is parallelSort using the Pool's workers: tasks are handed to a
+// worker if one is free (or the queue has room), or else sorted in the
+// goroutine that generated them.
+func (p *Pool) run(data sort.Interface, sorter sortFunc, initialTask task) {
+	if initialTask.end-initialTask.pos < initialTask.opts.minParallel() {
+		serialSort(data, sorter, initialTask)
 		return
 	}
 
+	var syncSort func(t task)
+	syncSort = func(t task) {
+		sorter(data, t, syncSort)
+	}
 	wg := new(sync.WaitGroup)
-	// buffer up one extra task to keep each cpu busy
-	sorts := make(chan task, int(float32(max)*bufferRatio))
 	var asyncSort func(t task)
 	asyncSort = func(t task) {
-		if t.end-t.pos < minOffload {
+		if t.end-t.pos < t.opts.minOffload() {
 			sorter(data, t, syncSort)
 			return
 		}
 		wg.Add(1)
-		select {
-		case sorts <- t:
-		default:
+		f := func() {
 			sorter(data, t, asyncSort)
 			wg.Done()
 		}
-	}
-	doSortWork := func() {
-		for task := range sorts {
-			sorter(data, task, asyncSort)
-			wg.Done()
+		if atomic.LoadInt32(&p.started) > 0 {
+			select {
+			case p.work <- f:
+				return
+			default:
+			}
 		}
-	}
-	for i := 0; i < max; i++ {
-		go doSortWork()
+		f()
 	}
 
 	asyncSort(initialTask)
 
 	wg.Wait()
-	close(sorts)
 }
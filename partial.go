@@ -0,0 +1,146 @@
+// Copyright 2015 Randall Farmer. All rights reserved.
+
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+package sorts
+
+import "sort"
+
+// PartialByUint64 puts the k lowest-keyed items of data in data[:k], in the
+// same order ByUint64 would, and leaves the rest of data in no particular
+// order.  It skips sorting buckets that lie entirely past k, so it's much
+// faster than a full sort when k is small relative to data.Len().  If k is
+// at least data.Len(), it's just ByUint64.
+func PartialByUint64(data Uint64Interface, k int) {
+	l := data.Len()
+	if k >= l {
+		ByUint64(data)
+		return
+	}
+	if k <= 0 {
+		return
+	}
+	if l < qSortCutoff {
+		qSort(data, 0, l)
+		return
+	}
+
+	sorter, t := uint64Sorter(data, 0, l, nil)
+	parallelSort(data, prefixOnly(k, sorter), t)
+
+	// check results!
+	checkUint64(data, 0, k)
+	checkPartial(data, k, l)
+}
+
+// PartialByInt64 is PartialByUint64 for int64 keys.
+func PartialByInt64(data Int64Interface, k int) {
+	l := data.Len()
+	if k >= l {
+		ByInt64(data)
+		return
+	}
+	if k <= 0 {
+		return
+	}
+	if l < qSortCutoff {
+		qSort(data, 0, l)
+		return
+	}
+
+	sorter, t := int64Sorter(data, 0, l, nil)
+	parallelSort(data, prefixOnly(k, sorter), t)
+
+	// check results!
+	checkInt64(data, 0, k)
+	checkPartial(data, k, l)
+}
+
+// prefixOnly wraps sorter to skip tasks that start at or after k, which is
+// enough to sort data[:k] since the radix sorts only ever hand off tasks
+// for whole buckets.
+func prefixOnly(k int, sorter sortFunc) sortFunc {
+	return func(data sort.Interface, t task, sortRange func(task)) {
+		if t.pos >= k {
+			return
+		}
+		sorter(data, t, sortRange)
+	}
+}
+
+// checkPartial panics if any item in data[k:l] sorts before data[k-1].
+func checkPartial(data sort.Interface, k, l int) {
+	if !Verify {
+		return
+	}
+	for i := k; i < l; i++ {
+		if data.Less(i, k-1) {
+			panic(panicMessage)
+		}
+	}
+}
+
+// SelectUint64 puts in data[k] the item ByUint64 would put there, with
+// items that sort before it in data[:k] and items that sort after it in
+// data[k+1:], each in no particular order, and returns its key.  It only
+// follows the bucket holding k at each radix pass, so it takes expected
+// linear time.  It panics if k is out of range.
+func SelectUint64(data Uint64Interface, k int) uint64 {
+	l := data.Len()
+	if k < 0 || k >= l {
+		panic("sorts: SelectUint64 index out of range")
+	}
+	if l < qSortCutoff {
+		qSort(data, 0, l)
+		return data.Key(k)
+	}
+
+	sorter, t := uint64Sorter(data, 0, l, nil)
+	parallelSort(data, containingOnly(k, sorter), t)
+
+	// check results!
+	checkSelect(data, k)
+	return data.Key(k)
+}
+
+// SelectInt64 is SelectUint64 for int64 keys.
+func SelectInt64(data Int64Interface, k int) int64 {
+	l := data.Len()
+	if k < 0 || k >= l {
+		panic("sorts: SelectInt64 index out of range")
+	}
+	if l < qSortCutoff {
+		qSort(data, 0, l)
+		return data.Key(k)
+	}
+
+	sorter, t := int64Sorter(data, 0, l, nil)
+	parallelSort(data, containingOnly(k, sorter), t)
+
+	// check results!
+	checkSelect(data, k)
+	return data.Key(k)
+}
+
+// containingOnly is prefixOnly, but skips every task not containing k.
+func containingOnly(k int, sorter sortFunc) sortFunc {
+	return func(data sort.Interface, t task, sortRange func(task)) {
+		if k < t.pos || k >= t.end {
+			return
+		}
+		sorter(data, t, sortRange)
+	}
+}
+
+// checkSelect panics if data isn't partitioned around data[k].
+func checkSelect(data sort.Interface, k int) {
+	if !Verify {
+		return
+	}
+	for i := 0; i < data.Len(); i++ {
+		if i < k && data.Less(k, i) || i > k && data.Less(i, k) {
+			panic(panicMessage)
+		}
+	}
+}
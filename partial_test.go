@@ -0,0 +1,129 @@
+// Copyright 2015 Randall Farmer. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sorts_test
+
+import (
+	"math/rand"
+	"testing"
+
+	. "github.com/twotwotwo/sorts"
+	. "github.com/twotwotwo/sorts/sortutil"
+)
+
+func TestPartialByUint64(t *testing.T) {
+	n := 100000
+	if testing.Short() {
+		n /= 10
+	}
+	orig := make([]uint64, n)
+	for i := range orig {
+		orig[i] = uint64(rand.Int63n(int64(n)))
+	}
+	sorted := append([]uint64(nil), orig...)
+	Uint64s(sorted)
+
+	for _, k := range []int{-1, 0, 1, 2, 10, 1000, n - 1, n, n + 5} {
+		varyQSortCutoff(func() {
+			data := append([]uint64(nil), orig...)
+			PartialByUint64(Uint64Slice(data), k)
+			top := k
+			if top > n {
+				top = n
+			}
+			for i := 0; i < top; i++ {
+				if data[i] != sorted[i] {
+					t.Fatalf("k=%d: data[%d] is %d, want %d", k, i, data[i], sorted[i])
+				}
+			}
+			// make sure nothing got lost
+			Uint64s(data)
+			for i := range data {
+				if data[i] != sorted[i] {
+					t.Fatalf("k=%d: partial sort changed data", k)
+				}
+			}
+		})
+	}
+}
+
+func TestPartialByInt64(t *testing.T) {
+	data := make([]int64, 10000)
+	for i := range data {
+		data[i] = rand.Int63n(20000) - 10000
+	}
+	sorted := append([]int64(nil), data...)
+	Int64s(sorted)
+	forceRadix(func() { PartialByInt64(Int64Slice(data), 100) })
+	for i := 0; i < 100; i++ {
+		if data[i] != sorted[i] {
+			t.Fatalf("data[%d] is %d, want %d", i, data[i], sorted[i])
+		}
+	}
+}
+
+func TestSelectUint64(t *testing.T) {
+	n := 100000
+	if testing.Short() {
+		n /= 10
+	}
+	orig := make([]uint64, n)
+	for i := range orig {
+		orig[i] = uint64(rand.Int63n(int64(n)))
+	}
+	sorted := append([]uint64(nil), orig...)
+	Uint64s(sorted)
+
+	for _, k := range []int{0, 1, n / 2, n - 1} {
+		varyQSortCutoff(func() {
+			data := append([]uint64(nil), orig...)
+			if got := SelectUint64(Uint64Slice(data), k); got != sorted[k] || data[k] != got {
+				t.Fatalf("k=%d: got %d, data[k] %d, want %d", k, got, data[k], sorted[k])
+			}
+			for i := range data {
+				if i < k && data[i] > data[k] || i > k && data[i] < data[k] {
+					t.Fatalf("k=%d: data[%d] is %d, on the wrong side of %d", k, i, data[i], data[k])
+				}
+			}
+		})
+	}
+
+	ints := make([]int64, 10000)
+	for i := range ints {
+		ints[i] = rand.Int63n(20000) - 10000
+	}
+	sortedInts := append([]int64(nil), ints...)
+	Int64s(sortedInts)
+	forceRadix(func() {
+		if got := SelectInt64(Int64Slice(ints), 5000); got != sortedInts[5000] {
+			t.Errorf("SelectInt64 got %d, want %d", got, sortedInts[5000])
+		}
+	})
+}
+
+func BenchmarkPartialByUint64Top100(b *testing.B) {
+	b.StopTimer()
+	data := make([]uint64, 1e6)
+	for i := 0; i < b.N; i++ {
+		for i := range data {
+			data[i] = uint64(rand.Int63())
+		}
+		b.StartTimer()
+		PartialByUint64(Uint64Slice(data), 100)
+		b.StopTimer()
+	}
+}
+
+func BenchmarkSelectUint64Median(b *testing.B) {
+	b.StopTimer()
+	data := make([]uint64, 1e6)
+	for i := 0; i < b.N; i++ {
+		for i := range data {
+			data[i] = uint64(rand.Int63())
+		}
+		b.StartTimer()
+		SelectUint64(Uint64Slice(data), len(data)/2)
+		b.StopTimer()
+	}
+}
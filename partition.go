@@ -0,0 +1,42 @@
+// Copyright 2015 Randall Farmer. All rights reserved.
+
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+package sorts
+
+// PartitionByUint64 splits data into buckets by the top bits of each
+// item's key, leaving items in no particular order within a bucket, then
+// calls fn with each bucket's index and range [a,b) of data, in order,
+// including empty buckets.  buckets must be a power of two from 1 to 1<<16;
+// with 256 buckets, bucket i holds keys whose top byte is i.  That's one
+// counting pass and one pass moving items, like the first pass of a radix
+// sort, so fn can sort or hand off each bucket on its own.  It runs in the
+// calling goroutine.
+func PartitionByUint64(data Uint64Interface, buckets int, fn func(bucketIdx, a, b int)) {
+	if buckets < 1 || buckets > 1<<maxWideRadix || buckets&(buckets-1) != 0 {
+		panic("sorts: PartitionByUint64 needs a power of two from 1 to 65536 buckets")
+	}
+	bits := uint(0)
+	for 1<<bits < buckets {
+		bits++
+	}
+	l := data.Len()
+	if bits == 0 {
+		fn(0, 0, l)
+		return
+	}
+
+	shift := 64 - bits
+	bucketStarts, bucketEnds := make([]int, buckets), make([]int, buckets)
+	for i := 0; i < l; i++ {
+		bucketStarts[data.Key(i)>>shift]++
+	}
+	scatterUint64(data, 0, shift, uint64(buckets-1), bucketStarts, bucketEnds)
+
+	pos := 0
+	for i, end := range bucketEnds {
+		fn(i, pos, end)
+		pos = end
+	}
+}
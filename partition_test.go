@@ -0,0 +1,60 @@
+// Copyright 2015 Randall Farmer. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sorts_test
+
+import (
+	"math/bits"
+	"math/rand"
+	"testing"
+
+	. "github.com/twotwotwo/sorts"
+	. "github.com/twotwotwo/sorts/sortutil"
+)
+
+func TestPartitionByUint64(t *testing.T) {
+	for _, buckets := range []int{1, 2, 16, 256, 1 << 16} {
+		a := make([]uint64, 100000)
+		for i := range a {
+			a[i] = rand.Uint64()
+		}
+		a[0], a[1] = 0, ^uint64(0)
+		sum := uint64(0)
+		for _, v := range a {
+			sum += v
+		}
+		next, seen := 0, 0
+		PartitionByUint64(Uint64Slice(a), buckets, func(bucket, lo, hi int) {
+			if bucket != next || lo != seen {
+				t.Fatalf("%d buckets: got bucket %d at %d, want %d at %d", buckets, bucket, lo, next, seen)
+			}
+			for _, v := range a[lo:hi] {
+				if int(v>>(64-bits.TrailingZeros(uint(buckets)))) != bucket {
+					t.Fatalf("%d buckets: %#x in bucket %d", buckets, v, bucket)
+				}
+			}
+			Uint64s(a[lo:hi]) // buckets can be sorted on their own
+			next, seen = next+1, hi
+		})
+		if next != buckets || seen != len(a) {
+			t.Errorf("%d buckets: called for %d buckets covering %d items", buckets, next, seen)
+		}
+		if !Uint64sAreSorted(a) {
+			t.Errorf("%d buckets: sorted buckets don't make a sorted slice", buckets)
+		}
+		for _, v := range a {
+			sum -= v
+		}
+		if sum != 0 {
+			t.Errorf("%d buckets: items lost", buckets)
+		}
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("3 buckets didn't panic")
+		}
+	}()
+	PartitionByUint64(Uint64Slice(nil), 3, func(int, int, int) {})
+}
@@ -0,0 +1,162 @@
+// Copyright 2015 Randall Farmer. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sorts_test
+
+import (
+	"bytes"
+	"math/rand"
+	"runtime"
+	"sort"
+	"strconv"
+	"sync"
+	"testing"
+
+	. "github.com/twotwotwo/sorts"
+	. "github.com/twotwotwo/sorts/sortutil"
+)
+
+func TestPool(t *testing.T) {
+	p := NewPool(4)
+	var wg sync.WaitGroup
+	for g := 0; g < 4; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for _, size := range []int{10, 1000, 100000} {
+				a := make([]int, size)
+				for i := range a {
+					a[i] = rand.Intn(1e9)
+				}
+				asBytes, asStrings, asUints := convertInts(a)
+				asUint64s := make([]uint64, len(asUints))
+				for i, v := range asUints {
+					asUint64s[i] = uint64(v)
+				}
+				p.ByInt64(IntSlice(a))
+				p.ByUint64(Uint64Slice(asUint64s))
+				p.ByString(StringSlice(asStrings))
+				p.ByBytes(BytesSlice(asBytes))
+				if !sort.IntsAreSorted(a) || !Uint64sAreSorted(asUint64s) ||
+					!sort.StringsAreSorted(asStrings) || !BytesAreSorted(asBytes) {
+					t.Errorf("size %d: not sorted", size)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	p.Close()
+}
+
+func TestPoolSmallBufferRatio(t *testing.T) {
+	defer SetBufferRatio(SetBufferRatio(0.01))
+	p := NewPool(4)
+	defer p.Close()
+	if n := QueueLen(p); n < 1 {
+		t.Errorf("pool queue holds %d tasks, want at least 1", n)
+	}
+	a := make([]int, 100000)
+	for i := range a {
+		a[i] = rand.Int()
+	}
+	p.ByInt64(IntSlice(a))
+	if !sort.IntsAreSorted(a) {
+		t.Errorf("not sorted")
+	}
+}
+
+// goroutineStrings notes which goroutines call Swap, to see whether a
+// string sort's buckets were spread across workers.
+type goroutineStrings struct {
+	StringSlice
+	mu    sync.Mutex
+	swaps int
+	seen  map[string]bool
+}
+
+func (g *goroutineStrings) Swap(i, j int) {
+	g.StringSlice.Swap(i, j)
+	g.mu.Lock()
+	g.swaps++
+	if g.swaps%64 == 0 {
+		buf := make([]byte, 64)
+		buf = buf[:runtime.Stack(buf, false)]
+		g.seen[string(bytes.Fields(buf)[1])] = true // "goroutine N [..."
+	}
+	g.mu.Unlock()
+}
+
+func TestPoolStringsParallel(t *testing.T) {
+	p := NewPool(4)
+	defer p.Close()
+	a := make([]string, 100000)
+	for i := range a {
+		a[i] = strconv.Itoa(rand.Int())
+	}
+	g := &goroutineStrings{StringSlice: a, seen: map[string]bool{}}
+	p.ByString(g)
+	if !sort.StringsAreSorted(a) {
+		t.Errorf("not sorted")
+	}
+	if len(g.seen) < 2 {
+		t.Errorf("string sort ran on %d goroutine(s), want several", len(g.seen))
+	}
+}
+
+func BenchmarkPoolSortInt64s1e5(b *testing.B) {
+	p := NewPool(0)
+	defer p.Close()
+	b.StopTimer()
+	data := make([]int, 1e5)
+	for i := 0; i < b.N; i++ {
+		for i := range data {
+			data[i] = rand.Int()
+		}
+		b.StartTimer()
+		p.ByInt64(IntSlice(data))
+		b.StopTimer()
+	}
+}
+
+// keyOnly sorts [key, id] pairs by key alone, so the order of ties shows.
+type keyOnly [][2]uint64
+
+func (p keyOnly) Len() int           { return len(p) }
+func (p keyOnly) Less(i, j int) bool { return p[i][0] < p[j][0] }
+func (p keyOnly) Swap(i, j int)      { p[i], p[j] = p[j], p[i] }
+func (p keyOnly) Key(i int) uint64   { return p[i][0] }
+
+func TestDeterministic(t *testing.T) {
+	defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(4))
+	defer func(old int) { MaxProcs = old }(MaxProcs)
+	n := 200000
+	if testing.Short() {
+		n /= 10
+	}
+	orig := make(keyOnly, n)
+	for i := range orig {
+		orig[i] = [2]uint64{uint64(rand.Intn(n / 4)), uint64(i)}
+	}
+
+	MaxProcs = 1
+	want := append(keyOnly(nil), orig...)
+	ByUint64(want)
+
+	MaxProcs = 0
+	p := NewPool(4)
+	defer p.Close()
+	for run := 0; run < 10; run++ {
+		got := append(keyOnly(nil), orig...)
+		if run&1 == 0 {
+			ByUint64(got)
+		} else {
+			p.ByUint64(got)
+		}
+		for i := range got {
+			if got[i] != want[i] {
+				t.Fatalf("run %d: item %d is %v, serial sort put %v there", run, i, got[i], want[i])
+			}
+		}
+	}
+}
@@ -0,0 +1,74 @@
+// Copyright 2015 Randall Farmer. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sorts_test
+
+import (
+	"math/rand"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+
+	. "github.com/twotwotwo/sorts/sortutil"
+)
+
+// longPrefixStrings returns n strings that share a header of the given
+// length, then (mostly) differ.  If short is set, a few shorter strings
+// and one that's just the header are mixed in.
+func longPrefixStrings(n, header int, short bool) []string {
+	h := strings.Repeat("x", header)
+	s := make([]string, n)
+	for i := range s {
+		switch {
+		case short && i == 0:
+			s[i] = h
+		case short && i%100 == 1:
+			s[i] = h[:rand.Intn(header)]
+		default:
+			s[i] = h + strconv.Itoa(rand.Intn(n)) + strings.Repeat("y", rand.Intn(40)) + strconv.Itoa(rand.Intn(n))
+		}
+	}
+	return s
+}
+
+func TestLongCommonPrefix(t *testing.T) {
+	for _, header := range []int{1, 31, 32, 33, 100, 4096} {
+		s := longPrefixStrings(5000, header, true)
+		b := make([][]byte, len(s))
+		for i := range s {
+			b[i] = []byte(s[i])
+		}
+		forceRadix(func() {
+			Strings(s)
+			Bytes(b)
+		})
+		if !sort.StringsAreSorted(s) {
+			t.Errorf("header %d: strings not sorted", header)
+		}
+		if !BytesAreSorted(b) {
+			t.Errorf("header %d: []bytes not sorted", header)
+		}
+	}
+}
+
+func BenchmarkSortLongPrefix(b *testing.B) {
+	b.StopTimer()
+	for i := 0; i < b.N; i++ {
+		data := longPrefixStrings(1e4, 4096, false)
+		b.StartTimer()
+		Strings(data)
+		b.StopTimer()
+	}
+}
+
+func BenchmarkSortShortPrefix(b *testing.B) {
+	b.StopTimer()
+	for i := 0; i < b.N; i++ {
+		data := longPrefixStrings(1e4, 8, false)
+		b.StartTimer()
+		Strings(data)
+		b.StopTimer()
+	}
+}
@@ -0,0 +1,78 @@
+// Copyright 2015 Randall Farmer. All rights reserved.
+
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+package sorts
+
+import (
+	"bytes"
+	"sort"
+)
+
+// The presorted functions check whether data[a:b] is already sorted, or
+// sorted backwards with no equal keys (so flipping it sorts it), and if so
+// finish the job and return true.  They bail at the first item out of
+// order, which on unsorted data is almost immediately, so they cost little
+// when they don't pay off.  Items with equal keys must also be in Less
+// order to count as sorted, as they would be after a radix sort.
+
+func uint64Presorted(data Uint64Interface, a, b int) bool {
+	prev := data.Key(a)
+	up, down := true, true
+	for i := a + 1; i < b && (up || down); i++ {
+		k := data.Key(i)
+		up = up && (k > prev || k == prev && !data.Less(i, i-1))
+		down = down && k < prev
+		prev = k
+	}
+	return finishPresorted(data, a, b, up, down)
+}
+
+func int64Presorted(data Int64Interface, a, b int) bool {
+	prev := data.Key(a)
+	up, down := true, true
+	for i := a + 1; i < b && (up || down); i++ {
+		k := data.Key(i)
+		up = up && (k > prev || k == prev && !data.Less(i, i-1))
+		down = down && k < prev
+		prev = k
+	}
+	return finishPresorted(data, a, b, up, down)
+}
+
+func stringPresorted(data StringInterface, a, b int) bool {
+	prev := data.Key(a)
+	up, down := true, true
+	for i := a + 1; i < b && (up || down); i++ {
+		k := data.Key(i)
+		up = up && (k > prev || k == prev && !data.Less(i, i-1))
+		down = down && k < prev
+		prev = k
+	}
+	return finishPresorted(data, a, b, up, down)
+}
+
+func bytesPresorted(data BytesInterface, a, b int) bool {
+	prev := data.Key(a)
+	up, down := true, true
+	for i := a + 1; i < b && (up || down); i++ {
+		k := data.Key(i)
+		c := bytes.Compare(k, prev)
+		up = up && (c > 0 || c == 0 && !data.Less(i, i-1))
+		down = down && c < 0
+		prev = k
+	}
+	return finishPresorted(data, a, b, up, down)
+}
+
+// finishPresorted flips data[a:b] if it was only in descending order, and
+// reports whether it's now sorted.
+func finishPresorted(data sort.Interface, a, b int, up, down bool) bool {
+	if down && !up {
+		for b--; a < b; a, b = a+1, b-1 {
+			data.Swap(a, b)
+		}
+	}
+	return up || down
+}
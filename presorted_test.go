@@ -0,0 +1,110 @@
+// Copyright 2015 Randall Farmer. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sorts_test
+
+import (
+	"math/rand"
+	"sort"
+	"strconv"
+	"testing"
+
+	. "github.com/twotwotwo/sorts"
+	. "github.com/twotwotwo/sorts/sortutil"
+)
+
+// swapCounter counts Swap calls.
+type swapCounter struct {
+	Uint64Slice
+	swaps *int
+}
+
+func (s swapCounter) Swap(i, j int) {
+	*s.swaps++
+	s.Uint64Slice.Swap(i, j)
+}
+
+// pairsByKey sorts pairs by their first value, then their second.
+type pairsByKey [][2]uint64
+
+func (p pairsByKey) Len() int { return len(p) }
+func (p pairsByKey) Less(i, j int) bool {
+	return p[i][0] < p[j][0] || p[i][0] == p[j][0] && p[i][1] < p[j][1]
+}
+func (p pairsByKey) Swap(i, j int)    { p[i], p[j] = p[j], p[i] }
+func (p pairsByKey) Key(i int) uint64 { return p[i][0] }
+
+func TestPresorted(t *testing.T) {
+	const n = 10000
+	a := make([]uint64, n)
+	for i := range a {
+		a[i] = uint64(i / 2)
+	}
+	swaps := 0
+	ByUint64(swapCounter{a, &swaps})
+	if swaps != 0 || !Uint64sAreSorted(a) {
+		t.Errorf("sorted input: %d swaps", swaps)
+	}
+
+	for i := range a {
+		a[i] = uint64(n - i)
+	}
+	swaps = 0
+	ByUint64(swapCounter{a, &swaps})
+	if swaps != n/2 || !Uint64sAreSorted(a) {
+		t.Errorf("reversed input: %d swaps, want %d", swaps, n/2)
+	}
+
+	// descending with ties can't just be flipped: ties would end up
+	// backwards by Less
+	p := make(pairsByKey, n)
+	for i := range p {
+		p[i] = [2]uint64{uint64(n - i/2), uint64(i)}
+	}
+	ByUint64(p)
+	if !sort.IsSorted(p) {
+		t.Errorf("descending input with ties didn't sort")
+	}
+	// ascending by key, but ties out of order by Less
+	for i := range p {
+		p[i] = [2]uint64{uint64(i / 2), uint64(n - i)}
+	}
+	ByUint64(p)
+	if !sort.IsSorted(p) {
+		t.Errorf("ascending keys with ties out of order didn't sort")
+	}
+
+	s := make([]string, n)
+	for i := range s {
+		s[i] = strconv.Itoa(n*2 - i)
+	}
+	Strings(s)
+	if !sort.StringsAreSorted(s) {
+		t.Errorf("reversed strings didn't sort")
+	}
+}
+
+func BenchmarkSortSortedUint64s1e6(b *testing.B) {
+	data := make([]uint64, 1e6)
+	for i := range data {
+		data[i] = uint64(i)
+	}
+	for i := 0; i < b.N; i++ {
+		Uint64s(data)
+	}
+}
+
+func BenchmarkSortAlmostSortedUint64s1e6(b *testing.B) {
+	b.StopTimer()
+	data := make([]uint64, 1e6)
+	for i := 0; i < b.N; i++ {
+		for i := range data {
+			data[i] = uint64(i)
+		}
+		data[rand.Intn(len(data))] = 0
+		b.StartTimer()
+		Uint64s(data)
+		b.StopTimer()
+	}
+}
@@ -165,6 +165,32 @@ func doPivot(data sort.Interface, lo, hi int) (midlo, midhi int) {
 	return b - 1, c
 }
 
+// quickMaxDepthFactor times ceil(lg(n+1)) is how deep quicksorts of n
+// items recurse before switching to heapsort.
+var quickMaxDepthFactor = 2
+
+// SetQuickMaxDepthFactor sets how deep quicksorts recurse before falling
+// back to heapsort: f*ceil(lg(n+1)) levels for n items.  The default, 2,
+// matches package sort.  Lowering it gives up on bad pivots sooner, which
+// limits the damage from adversarial input but sends more ordinary input
+// to the slower heapsort; raising it does the opposite.  0 means to always
+// heapsort.  Like MaxProcs, it shouldn't be changed while sorts are running.
+func SetQuickMaxDepthFactor(f int) {
+	if f < 0 {
+		panic("sorts: negative quicksort depth factor")
+	}
+	quickMaxDepthFactor = f
+}
+
+// quickMaxDepth is the depth limit for a quicksort of n items.
+func quickMaxDepth(n int) int {
+	maxDepth := 0
+	for i := n; i > 0; i >>= 1 {
+		maxDepth++
+	}
+	return maxDepth * quickMaxDepthFactor
+}
+
 func quickSort(data sort.Interface, a, b, maxDepth int) {
 	for b-a > 12 {
 		if maxDepth == 0 {
@@ -198,45 +224,28 @@ func quickSort(data sort.Interface, a, b, maxDepth int) {
 // qSort quicksorts data immediately.
 // It performs O(n*log(n)) comparisons and swaps. The sort is not stable.
 func qSort(data sort.Interface, a, b int) {
-	// Switch to heapsort if depth of 2*ceil(lg(n+1)) is reached.
-	n := b - a
-	maxDepth := 0
-	for i := n; i > 0; i >>= 1 {
-		maxDepth++
-	}
-	maxDepth *= 2
-	quickSort(data, a, b, maxDepth)
+	quickSort(data, a, b, quickMaxDepth(b-a))
 }
 
 // Quicksort performs a parallel quicksort on data.
 func Quicksort(data sort.Interface) {
 	a, b := 0, data.Len()
-	n := b - a
-	maxDepth := 0
-	for i := n; i > 0; i >>= 1 {
-		maxDepth++
-	}
-	maxDepth *= 2
-	parallelSort(data, quickSortWorker, task{-maxDepth - 1, a, b})
+	maxDepth := quickMaxDepth(b - a)
+	parallelSort(data, quickSortWorker, task{offs: -maxDepth - 1, pos: a, end: b})
 }
 
 // qSortPar starts a parallel quicksort.
 func qSortPar(data sort.Interface, t task, sortRange func(task)) {
 	a, b := t.pos, t.end
-	n := b - a
-	maxDepth := 0
-	for i := n; i > 0; i >>= 1 {
-		maxDepth++
-	}
-	maxDepth *= 2
-	quickSortWorker(data, task{-maxDepth - 1, a, b}, sortRange)
+	maxDepth := quickMaxDepth(b - a)
+	quickSortWorker(data, task{offs: -maxDepth - 1, pos: a, end: b, opts: t.opts}, sortRange)
 }
 
 // quickSortWorker is a parallel analogue of quickSort: it performs a pivot
 // and might asynchronously sort one of the halves if it's large enough.
 func quickSortWorker(data sort.Interface, t task, sortRange func(task)) {
-	maxDepth, a, b := 1-t.offs, t.pos, t.end
-	for b-a > minOffload {
+	maxDepth, a, b := -t.offs-1, t.pos, t.end
+	for b-a > t.opts.minOffload() {
 		if maxDepth == 0 {
 			heapSort(data, a, b)
 			return
@@ -246,10 +255,10 @@ func quickSortWorker(data sort.Interface, t task, sortRange func(task)) {
 		// Avoiding recursion on the larger subproblem guarantees
 		// a stack depth of at most lg(b-a).
 		if mlo-a < b-mhi {
-			sortRange(task{-maxDepth - 1, a, mlo})
+			sortRange(task{offs: -maxDepth - 1, pos: a, end: mlo, opts: t.opts})
 			a = mhi // i.e., quickSortWorker(data, mhi, b)
 		} else {
-			sortRange(task{-maxDepth - 1, mhi, b})
+			sortRange(task{offs: -maxDepth - 1, pos: mhi, end: b, opts: t.opts})
 			b = mlo // i.e., quickSortWorker(data, a, mlo)
 		}
 	}
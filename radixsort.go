@@ -8,6 +8,7 @@ package sorts
 import (
 	"bytes"
 	"sort"
+	"strings"
 )
 
 const radix = 8
@@ -15,13 +16,30 @@ const mask = (1 << radix) - 1
 
 // qSortCutoff is when we bail out to a quicksort. It's changed to 1 for
 // certain tests so we can more easily exercise the radix sorting.  This was
-// around the break-even point in some sloppy tests.
+// around the break-even point in some sloppy tests; AutoTune measures it
+// on the machine at hand.
 var qSortCutoff = 1 << 7
 
 const keyPanicMessage = "sort failed: Key and Less aren't consistent with each other"
 const keyUint64Help = " (for float data, sortutil Key functions may help resolve this)"
 const panicMessage = "sort failed: could be a data race, a bug in package sorts, or a subtle bug in the interface implementation"
 
+// PreferFewerPasses makes ByUint64 and ByInt64 use a radix of up to 16 bits
+// on large collections, trading memory for passes: 32-bit keys take two
+// passes rather than four, but each pass can use 1MB of count tables, and
+// scattering items into 64K buckets misses cache more. Benchmark your data
+// (see BenchmarkSortUint32Range1e6FewerPasses) before turning it on.  Set
+// it before sorting starts, as with MaxProcs.
+var PreferFewerPasses = false
+
+// Verify makes sorts check that data is sorted when they're done, and
+// panic if it isn't.  The check catches races, inconsistent Key and Less
+// methods, and bugs in this package, and costs an extra pass over the data.
+// Turning it off is unsafe--a broken sort will go unnoticed--and only
+// recommended for code whose sorts have been well tested with it on.  Set
+// it before sorting starts, as with MaxProcs.
+var Verify = true
+
 // maxRadixDepth limits how deeply the radix part of string sorts can
 // recurse before we bail to quicksort.  Each recursion uses 2KB stack.
 const maxRadixDepth = 32
@@ -29,29 +47,66 @@ const maxRadixDepth = 32
 // task describes a range of data to be sorted and additional
 // information the sorter needs: bitshift in a numeric sort, byte offset in
 // a string sort, or maximum depth (expressed as -maxDepth-1) for a
-// quicksort.
-type task struct{ offs, pos, end int }
+// quicksort.  String sorts also track their recursion depth, which can
+// lag the offset when they skip past a prefix all keys share.  opts, if
+// not nil, holds settings for this sort that override the package's.
+type task struct {
+	offs, pos, end, depth int
+	opts                  *Options
+}
 
-// ByUint64 sorts data by a uint64 key.
+// ByUint64 sorts data by a uint64 key.  If data is a BulkKeyInterface,
+// it gets the keys with KeysInto.
 func ByUint64(data Uint64Interface) {
-	l := data.Len()
-	if l < qSortCutoff {
-		qSort(data, 0, l)
+	if bulk, ok := data.(BulkKeyInterface); ok && data.Len() >= qSortCutoff {
+		byBulkKeys(bulk)
+		return
+	}
+	ByUint64Range(data, 0, data.Len())
+}
+
+// ByUint64Range sorts data[a:b] by a uint64 key, leaving the rest of data
+// untouched.
+func ByUint64Range(data Uint64Interface, a, b int) { byUint64Range(data, a, b, parallelSort, nil) }
+
+// byUint64Range is ByUint64Range, using run to do the radix sort.
+func byUint64Range(data Uint64Interface, a, b int, run runner, opts *Options) {
+	checkRange(data, a, b)
+	if b-a < 2 {
+		return // nothing to sort or check
+	}
+	if b-a < opts.qSortCutoff() {
+		qSortUint64(data, a, b)
 		return
 	}
-	
-	shift := guessIntShift(data, l)
-	parallelSort(data, radixSortUint64, task{offs: int(shift), end: l})
+	if !uint64Presorted(data, a, b) {
+		sorter, t := uint64Sorter(data, a, b, opts)
+		t.opts = opts
+		run(data, opts.wrap(sorter, t), t)
+	}
 
 	// check results if we radix sorted!
-	for i := 1; i < l; i++ {
-		if data.Less(i, i-1) {
-			if data.Key(i) > data.Key(i-1) {
-				panic(keyPanicMessage + keyUint64Help)
-			}
-			panic(panicMessage)
+	if opts.check() {
+		checkUint64(data, a, b)
+	}
+}
+
+// uint64Sorter picks the sortFunc and initial task for radix sorting
+// data[a:b].
+func uint64Sorter(data Uint64Interface, a, b int, opts *Options) (sortFunc, task) {
+	if PreferFewerPasses {
+		if width, shift := wideRadix(data, a, b); width > radix {
+			return wideRadixSorter(width), task{offs: shift, pos: a, end: b}
 		}
 	}
+	if width, ok := countingRadix(data, a, b); ok {
+		return wideRadixSorter(width), task{offs: 0, pos: a, end: b}
+	}
+	shift := guessIntShift(data, a, b)
+	if opts.poolTables() {
+		return pooledRadixSortUint64, task{offs: int(shift), pos: a, end: b}
+	}
+	return radixSortUint64, task{offs: int(shift), pos: a, end: b}
 }
 
 // int64Key generates a uint64 from an int64
@@ -66,18 +121,145 @@ func (iw intwrapper) Key(i int) uint64 {
 }
 
 // ByInt64 sorts data by an int64 key.
-func ByInt64(data Int64Interface) {
-	l := data.Len()
-	if l < qSortCutoff {
-		qSort(data, 0, l)
+func ByInt64(data Int64Interface) { ByInt64Range(data, 0, data.Len()) }
+
+// ByInt64Range sorts data[a:b] by an int64 key, leaving the rest of data
+// untouched.
+func ByInt64Range(data Int64Interface, a, b int) { byInt64Range(data, a, b, parallelSort, nil) }
+
+// byInt64Range is ByInt64Range, using run to do the radix sort.
+func byInt64Range(data Int64Interface, a, b int, run runner, opts *Options) {
+	checkRange(data, a, b)
+	if b-a < 2 {
+		return // nothing to sort or check
+	}
+	if b-a < opts.qSortCutoff() {
+		qSortInt64(data, a, b)
 		return
 	}
-
-	shift := guessIntShift(intwrapper{data}, l)
-	parallelSort(data, radixSortInt64, task{offs: int(shift), end: l})
+	if !int64Presorted(data, a, b) {
+		sorter, t := int64Sorter(data, a, b, opts)
+		t.opts = opts
+		run(data, opts.wrap(sorter, t), t)
+	}
 
 	// check results!
-	for i := 1; i < l; i++ {
+	if opts.check() {
+		checkInt64(data, a, b)
+	}
+}
+
+// int64Sorter is uint64Sorter for int64 keys.
+func int64Sorter(data Int64Interface, a, b int, opts *Options) (sortFunc, task) {
+	if PreferFewerPasses {
+		if width, shift := wideRadix(intwrapper{data}, a, b); width > radix {
+			return wideRadixSorter(width), task{offs: shift, pos: a, end: b}
+		}
+	}
+	if width, ok := countingRadix(intwrapper{data}, a, b); ok {
+		return wideRadixSorter(width), task{offs: 0, pos: a, end: b}
+	}
+	shift := guessIntShift(intwrapper{data}, a, b)
+	if opts.poolTables() {
+		return pooledRadixSortInt64, task{offs: int(shift), pos: a, end: b}
+	}
+	return radixSortInt64, task{offs: int(shift), pos: a, end: b}
+}
+
+// ByString sorts data by a string key.  Like the integer sorts, it hands
+// buckets of large collections off to other goroutines, as MaxProcs allows.
+func ByString(data StringInterface) { ByStringRange(data, 0, data.Len()) }
+
+// ByStringRange sorts data[a:b] by a string key, leaving the rest of data
+// untouched.
+func ByStringRange(data StringInterface, a, b int) { byStringRange(data, a, b, parallelSort, nil) }
+
+// byStringRange is ByStringRange, using run to do the radix sort.
+func byStringRange(data StringInterface, a, b int, run runner, opts *Options) {
+	checkRange(data, a, b)
+	if b-a < 2 {
+		return // nothing to sort or check
+	}
+	if b-a < opts.qSortCutoff() {
+		qSort(data, a, b)
+		return
+	}
+	if !stringPresorted(data, a, b) {
+		t := task{offs: 0, pos: a, end: b, opts: opts}
+		sorter := radixSortString
+		if opts.poolTables() {
+			sorter = pooledRadixSortString
+		}
+		run(data, opts.wrap(sorter, t), t)
+	}
+
+	// check results if we radix sorted!
+	if opts.check() {
+		checkString(data, a, b)
+	}
+}
+
+// ByBytes sorts data by a []byte key.
+func ByBytes(data BytesInterface) { ByBytesRange(data, 0, data.Len()) }
+
+// ByBytesRange sorts data[a:b] by a []byte key, leaving the rest of data
+// untouched.
+func ByBytesRange(data BytesInterface, a, b int) { byBytesRange(data, a, b, parallelSort, nil) }
+
+// byBytesRange is ByBytesRange, using run to do the radix sort.
+func byBytesRange(data BytesInterface, a, b int, run runner, opts *Options) {
+	checkRange(data, a, b)
+	if b-a < 2 {
+		return // nothing to sort or check
+	}
+	if b-a < opts.qSortCutoff() {
+		qSort(data, a, b)
+		return
+	}
+	if !bytesPresorted(data, a, b) {
+		t := task{offs: 0, pos: a, end: b, opts: opts}
+		sorter := radixSortBytes
+		if opts.poolTables() {
+			sorter = pooledRadixSortBytes
+		}
+		run(data, opts.wrap(sorter, t), t)
+	}
+
+	// check results if we radix sorted!
+	if opts.check() {
+		checkBytes(data, a, b)
+	}
+}
+
+// ByBytesFixed sorts data by a []byte key of keyLen bytes, like a hash or
+// UUID.  Where ByBytes gives up on radix sorting after 32 levels and
+// quicksorts the rest, ByBytesFixed keeps going for as many levels as the
+// keys have bytes, so keys that are all keyLen long never need to be
+// compared.  Longer keys still sort correctly.  If data can report the
+// key length itself, ByFixedBytes is a little leaner.
+func ByBytesFixed(data BytesInterface, keyLen int) {
+	opts := &Options{}
+	if keyLen >= maxRadixDepth {
+		// one level past the end of the keys finds them all equal
+		opts.maxRadixDepth = keyLen + 1
+	}
+	byBytesRange(data, 0, data.Len(), parallelSort, opts)
+}
+
+// checkRange panics if [a,b) isn't a valid range of data.
+func checkRange(data sort.Interface, a, b int) {
+	if a < 0 || a > b || b > data.Len() {
+		panic("sorts: range out of bounds")
+	}
+}
+
+// checkUint64 panics if data[a:b] isn't sorted, with a more helpful
+// message if it's because Key and Less disagree.
+func checkUint64(data Uint64Interface, a, b int) {
+	if !Verify {
+		return
+	}
+	for i := a + 1; i < b; i++ {
 		if data.Less(i, i-1) {
 			if data.Key(i) > data.Key(i-1) {
 				panic(keyPanicMessage + keyUint64Help)
@@ -87,18 +269,27 @@ func ByInt64(data Int64Interface) {
 	}
 }
 
-// ByString sorts data by a string key.
-func ByString(data StringInterface) {
-	l := data.Len()
-	if l < qSortCutoff {
-		qSort(data, 0, l)
+// checkInt64 is checkUint64 for int64 keys.
+func checkInt64(data Int64Interface, a, b int) {
+	if !Verify {
 		return
 	}
+	for i := a + 1; i < b; i++ {
+		if data.Less(i, i-1) {
+			if data.Key(i) > data.Key(i-1) {
+				panic(keyPanicMessage + keyUint64Help)
+			}
+			panic(panicMessage)
+		}
+	}
+}
 
-	parallelSort(data, radixSortString, task{end: l})
-
-	// check results if we radix sorted!
-	for i := 1; i < l; i++ {
+// checkString is checkUint64 for string keys.
+func checkString(data StringInterface, a, b int) {
+	if !Verify {
+		return
+	}
+	for i := a + 1; i < b; i++ {
 		if data.Less(i, i-1) {
 			if data.Key(i) > data.Key(i-1) {
 				panic(keyPanicMessage)
@@ -108,18 +299,12 @@ func ByString(data StringInterface) {
 	}
 }
 
-// ByBytes sorts data by a []byte key.
-func ByBytes(data BytesInterface) {
-	l := data.Len()
-	if l < qSortCutoff {
-		qSort(data, 0, l)
+// checkBytes is checkUint64 for []byte keys.
+func checkBytes(data BytesInterface, a, b int) {
+	if !Verify {
 		return
 	}
-
-	parallelSort(data, radixSortBytes, task{end: l})
-
-	// check results if we radix sorted!
-	for i := 1; i < l; i++ {
+	for i := a + 1; i < b; i++ {
 		if data.Less(i, i-1) {
 			if bytes.Compare(data.Key(i), data.Key(i-1)) > 0 {
 				panic(keyPanicMessage)
@@ -134,17 +319,29 @@ func ByBytes(data BytesInterface) {
 // hurts much otherwise: either it just returns 64-radix quickly, or it
 // returns too small a shift and the sort notices after one useless counting
 // pass.
-func guessIntShift(data Uint64Interface, l int) uint {
-	step := l >> 5
-	if l > 1<<16 {
-		step = l >> 8
+func guessIntShift(data Uint64Interface, a, b int) uint {
+	shiftGuess := guessIntBits(data, a, b) - radix
+	if shiftGuess < 0 {
+		return 0
 	}
+	return uint(shiftGuess)
+}
+
+// guessIntBits estimates how many low bits vary across the keys in
+// data[a:b], from about 256 evenly spaced keys.  That's enough to usually
+// catch outliers that make up a percent or so of the data.  Rarer ones
+// (like a lone -1) it misses, but a full scan wouldn't pay: guessing too
+// low only wastes one counting pass, which costs about what reading every
+// key would.
+func guessIntBits(data Uint64Interface, a, b int) int {
+	l := b - a
+	step := l >> 8
 	if step == 0 { // only for tests w/qSortCutoff lowered
 		step = 1
 	}
-	min := data.Key(l - 1)
+	min := data.Key(b - 1)
 	max := min
-	for i := 0; i < l; i += step {
+	for i := a; i < b; i += step {
 		k := data.Key(i)
 		if k < min {
 			min = k
@@ -159,11 +356,7 @@ func guessIntShift(data Uint64Interface, l int) uint {
 		log2diff++
 		diff >>= 1
 	}
-	shiftGuess := log2diff - radix
-	if shiftGuess < 0 {
-		return 0
-	}
-	return uint(shiftGuess)
+	return log2diff
 }
 
 /*
@@ -210,16 +403,22 @@ for laying out American flag sort
 // across the whole range being sorted.
 
 func radixSortUint64(dataI sort.Interface, t task, sortRange func(task)) {
+	var tbl bucketTables
+	radixSortUint64Tables(dataI, t, sortRange, &tbl)
+}
+
+// radixSortUint64Tables is radixSortUint64 using the zeroed tables in tbl.
+func radixSortUint64Tables(dataI sort.Interface, t task, sortRange func(task), tbl *bucketTables) {
 	data := dataI.(Uint64Interface)
 	shift, a, b := uint(t.offs), t.pos, t.end
-	if b-a < qSortCutoff {
-		qSort(data, a, b)
+	if b-a < t.opts.qSortCutoff() {
+		qSortUint64(data, a, b)
 		return
 	}
 
 	// use a single pass over the keys to bucket data and find min/max
 	// (for skipping over bits that are always identical)
-	var bucketStarts, bucketEnds [1 << radix]int
+	bucketStarts, bucketEnds := &tbl.starts, &tbl.ends
 	min := data.Key(a)
 	max := min
 	for i := a; i < b; i++ {
@@ -250,7 +449,7 @@ func radixSortUint64(dataI sort.Interface, t task, sortRange func(task)) {
 		if nextShift < 0 {
 			nextShift = 0
 		}
-		sortRange(task{nextShift, a, b})
+		sortRange(task{offs: nextShift, pos: a, end: b, opts: t.opts})
 		return
 	}
 
@@ -286,6 +485,10 @@ func radixSortUint64(dataI sort.Interface, t task, sortRange func(task)) {
 		return
 	}
 
+	// shift needn't be a multiple of radix (guessed or caller-supplied
+	// shifts usually aren't), so below radix, drop to 0 rather than
+	// underflow: the next pass re-reads a few bits that are equal within
+	// each bucket, but covers every bit below shift.
 	nextShift := shift - radix
 	if shift < radix {
 		nextShift = 0
@@ -293,23 +496,29 @@ func radixSortUint64(dataI sort.Interface, t task, sortRange func(task)) {
 	pos = a
 	for _, end := range bucketEnds {
 		if end > pos+1 {
-			sortRange(task{int(nextShift), pos, end})
+			sortRange(task{offs: int(nextShift), pos: pos, end: end, opts: t.opts})
 		}
 		pos = end
 	}
 }
 
 func radixSortInt64(dataI sort.Interface, t task, sortRange func(task)) {
+	var tbl bucketTables
+	radixSortInt64Tables(dataI, t, sortRange, &tbl)
+}
+
+// radixSortInt64Tables is radixSortInt64 using the zeroed tables in tbl.
+func radixSortInt64Tables(dataI sort.Interface, t task, sortRange func(task), tbl *bucketTables) {
 	data := dataI.(Int64Interface)
 	shift, a, b := uint(t.offs), t.pos, t.end
-	if b-a < qSortCutoff {
-		qSort(data, a, b)
+	if b-a < t.opts.qSortCutoff() {
+		qSortInt64(data, a, b)
 		return
 	}
 
 	// use a single pass over the keys to bucket data and find min/max
 	// (for skipping over bits that are always identical)
-	var bucketStarts, bucketEnds [1 << radix]int
+	bucketStarts, bucketEnds := &tbl.starts, &tbl.ends
 	min := int64Key(data.Key(a))
 	max := min
 	for i := a; i < b; i++ {
@@ -340,7 +549,7 @@ func radixSortInt64(dataI sort.Interface, t task, sortRange func(task)) {
 		if nextShift < 0 {
 			nextShift = 0
 		}
-		sortRange(task{nextShift, a, b})
+		sortRange(task{offs: nextShift, pos: a, end: b, opts: t.opts})
 		return
 	}
 
@@ -377,6 +586,10 @@ func radixSortInt64(dataI sort.Interface, t task, sortRange func(task)) {
 		return
 	}
 
+	// shift needn't be a multiple of radix (guessed or caller-supplied
+	// shifts usually aren't), so below radix, drop to 0 rather than
+	// underflow: the next pass re-reads a few bits that are equal within
+	// each bucket, but covers every bit below shift.
 	nextShift := shift - radix
 	if shift < radix {
 		nextShift = 0
@@ -384,13 +597,19 @@ func radixSortInt64(dataI sort.Interface, t task, sortRange func(task)) {
 	pos = a
 	for _, end := range bucketEnds {
 		if end > pos+1 {
-			sortRange(task{int(nextShift), pos, end})
+			sortRange(task{offs: int(nextShift), pos: pos, end: end, opts: t.opts})
 		}
 		pos = end
 	}
 }
 
 func radixSortString(dataI sort.Interface, t task, sortRange func(task)) {
+	var tbl bucketTables
+	radixSortStringTables(dataI, t, sortRange, &tbl)
+}
+
+// radixSortStringTables is radixSortString using the zeroed tables in tbl.
+func radixSortStringTables(dataI sort.Interface, t task, sortRange func(task), tbl *bucketTables) {
 	data := dataI.(StringInterface)
 	offset, a, b := t.offs, t.pos, t.end
 	if offset < 0 {
@@ -398,18 +617,21 @@ func radixSortString(dataI sort.Interface, t task, sortRange func(task)) {
 		quickSortWorker(data, t, sortRange)
 		return
 	}
-	if b-a < qSortCutoff {
+	if b-a < t.opts.qSortCutoff() {
 		qSort(data, a, b)
 		return
 	}
-	if offset == maxRadixDepth {
+	if t.depth == t.opts.radixDepth() {
 		qSortPar(data, t, sortRange)
 		return
 	}
 
-	// swap too-short strings to start and count bucket sizes
-	bucketStarts, bucketEnds := [256]int{}, [256]int{}
+	// swap too-short strings to start, count bucket sizes, and find how
+	// long a prefix the rest share
+	bucketStarts, bucketEnds := &tbl.starts, &tbl.ends
 	aInitial := a
+	var first string
+	common := -1
 	for i := a; i < b; i++ {
 		k := data.Key(i)
 		if len(k) <= offset {
@@ -419,6 +641,11 @@ func radixSortString(dataI sort.Interface, t task, sortRange func(task)) {
 			continue
 		}
 		bucketStarts[k[offset]]++
+		if common < 0 {
+			first, common = k, len(k)-offset
+		} else if common > 0 && !strings.HasPrefix(k[offset:], first[offset:offset+common]) {
+			common = commonPrefixString(first[offset:offset+common], k[offset:])
+		}
 	}
 	if a > aInitial+1 {
 		qSortEqualKeyRange(data, aInitial, a)
@@ -430,8 +657,9 @@ func radixSortString(dataI sort.Interface, t task, sortRange func(task)) {
 		pos += c
 		bucketEnds[i] = pos
 		if bucketStarts[i] == a && bucketEnds[i] == b {
-			// everything was in the same bucket
-			sortRange(task{offset + 1, a, b})
+			// everything was in the same bucket, so skip past the
+			// prefix they all share
+			sortRange(task{offs: offset + common, pos: a, end: b, depth: t.depth + 1, opts: t.opts})
 			return
 		}
 	}
@@ -451,12 +679,18 @@ func radixSortString(dataI sort.Interface, t task, sortRange func(task)) {
 			bucketStarts[destBucket]++
 		}
 		if i > start+1 {
-			sortRange(task{offset + 1, start, i})
+			sortRange(task{offs: offset + 1, pos: start, end: i, depth: t.depth + 1, opts: t.opts})
 		}
 	}
 }
 
 func radixSortBytes(dataI sort.Interface, t task, sortRange func(task)) {
+	var tbl bucketTables
+	radixSortBytesTables(dataI, t, sortRange, &tbl)
+}
+
+// radixSortBytesTables is radixSortBytes using the zeroed tables in tbl.
+func radixSortBytesTables(dataI sort.Interface, t task, sortRange func(task), tbl *bucketTables) {
 	data := dataI.(BytesInterface)
 	offset, a, b := t.offs, t.pos, t.end
 	if offset < 0 {
@@ -464,18 +698,21 @@ func radixSortBytes(dataI sort.Interface, t task, sortRange func(task)) {
 		quickSortWorker(data, t, sortRange)
 		return
 	}
-	if b-a < qSortCutoff {
+	if b-a < t.opts.qSortCutoff() {
 		qSort(data, a, b)
 		return
 	}
-	if offset == maxRadixDepth {
+	if t.depth == t.opts.radixDepth() {
 		qSortPar(data, t, sortRange)
 		return
 	}
 
-	// swap too-short strings to start and count bucket sizes
-	bucketStarts, bucketEnds := [256]int{}, [256]int{}
+	// swap too-short strings to start, count bucket sizes, and find how
+	// long a prefix the rest share
+	bucketStarts, bucketEnds := &tbl.starts, &tbl.ends
 	aInitial := a
+	var first []byte
+	common := -1
 	for i := a; i < b; i++ {
 		k := data.Key(i)
 		if len(k) <= offset {
@@ -485,6 +722,11 @@ func radixSortBytes(dataI sort.Interface, t task, sortRange func(task)) {
 			continue
 		}
 		bucketStarts[k[offset]]++
+		if common < 0 {
+			first, common = k, len(k)-offset
+		} else if common > 0 && !bytes.HasPrefix(k[offset:], first[offset:offset+common]) {
+			common = commonPrefixBytes(first[offset:offset+common], k[offset:])
+		}
 	}
 	if a > aInitial+1 {
 		qSortEqualKeyRange(data, aInitial, a)
@@ -496,8 +738,9 @@ func radixSortBytes(dataI sort.Interface, t task, sortRange func(task)) {
 		pos += c
 		bucketEnds[i] = pos
 		if bucketStarts[i] == a && bucketEnds[i] == b {
-			// everything was in the same bucket
-			sortRange(task{offset + 1, a, b})
+			// everything was in the same bucket, so skip past the
+			// prefix they all share
+			sortRange(task{offs: offset + common, pos: a, end: b, depth: t.depth + 1, opts: t.opts})
 			return
 		}
 	}
@@ -517,11 +760,30 @@ func radixSortBytes(dataI sort.Interface, t task, sortRange func(task)) {
 			bucketStarts[destBucket]++
 		}
 		if i > start+1 {
-			sortRange(task{offset + 1, start, i})
+			sortRange(task{offs: offset + 1, pos: start, end: i, depth: t.depth + 1, opts: t.opts})
 		}
 	}
 }
 
+// commonPrefixString returns the length of the longest prefix s and t
+// share.
+func commonPrefixString(s, t string) int {
+	i := 0
+	for i < len(s) && i < len(t) && s[i] == t[i] {
+		i++
+	}
+	return i
+}
+
+// commonPrefixBytes is commonPrefixString for []byte.
+func commonPrefixBytes(s, t []byte) int {
+	i := 0
+	for i < len(s) && i < len(t) && s[i] == t[i] {
+		i++
+	}
+	return i
+}
+
 // qSortEqualKeyRange qSorts data[a:b] if it is not already sorted
 func qSortEqualKeyRange(data sort.Interface, a, b int) {
 	for i := a; i < b-1; i++ {
@@ -11,6 +11,7 @@ import (
 	"bytes"
 	"fmt"
 	"math/rand"
+	"reflect"
 	"sort"
 	"strconv"
 	"testing"
@@ -101,6 +102,21 @@ func TestSortCheck(t *testing.T) {
 	})
 }
 
+func TestVerifyOff(t *testing.T) {
+	defer func(old bool) { Verify = old }(Verify)
+	Verify = false
+	forceRadix(func() {
+		// would panic if checked
+		ByInt64(miskeyedInts{IntSlice{1, 2, 3}})
+		ByString(miskeyedStrings{StringSlice{"a", "b", "c"}})
+	})
+	data := []int{3, 1, 2}
+	forceRadix(func() { Ints(data) })
+	if !IntsAreSorted(data) {
+		t.Errorf("sort without verification didn't sort")
+	}
+}
+
 func TestFlip(t *testing.T) {
 	data1, expected1 := [...]int{1, 2, 3, 4, 5}, [...]int{5, 4, 3, 2, 1}
 	Flip(IntSlice(data1[:]))
@@ -667,6 +683,59 @@ func TestAdversary(t *testing.T) {
 	Quicksort(d)
 }
 
+func TestQuickMaxDepthFactor(t *testing.T) {
+	defer SetQuickMaxDepthFactor(2)
+	for _, f := range []int{0, 1, 8} {
+		SetQuickMaxDepthFactor(f)
+		data := make([]int, 100)
+		for i := range data {
+			data[i] = i
+		}
+		Quicksort(&adversaryTestingData{data, make(map[int]int), 0})
+		Quicksort(&adversaryTestingData{data, make(map[int]int), 0})
+
+		for i := range data {
+			data[i] = rand.Intn(50)
+		}
+		Quicksort(IntSlice(data))
+		if !sort.IntsAreSorted(data) {
+			t.Errorf("factor %d: not sorted", f)
+		}
+	}
+}
+
+// swapLog records the swaps made sorting an IntSlice.
+type swapLog struct {
+	IntSlice
+	swaps [][2]int
+}
+
+func (l *swapLog) Swap(i, j int) {
+	l.swaps = append(l.swaps, [2]int{i, j})
+	l.IntSlice.Swap(i, j)
+}
+
+// TestQuickMaxDepthFactorZero checks that factor 0 sends Quicksort
+// straight to heapsort, making just the swaps Heapsort would.
+func TestQuickMaxDepthFactorZero(t *testing.T) {
+	defer SetQuickMaxDepthFactor(2)
+	SetQuickMaxDepthFactor(0)
+	data := make([]int, 1000)
+	for i := range data {
+		data[i] = rand.Int()
+	}
+	heap := &swapLog{IntSlice: append([]int(nil), data...)}
+	Heapsort(heap)
+	quick := &swapLog{IntSlice: data}
+	Quicksort(quick)
+	if !sort.IntsAreSorted(data) {
+		t.Fatal("not sorted")
+	}
+	if !reflect.DeepEqual(quick.swaps, heap.swaps) {
+		t.Errorf("Quicksort with factor 0 made %d swaps, not Heapsort's %d", len(quick.swaps), len(heap.swaps))
+	}
+}
+
 func BenchmarkSort1e2(b *testing.B) { bench(b, 1e2, byInt64Wrapper, "Sort") }
 func BenchmarkSort1e4(b *testing.B) { bench(b, 1e4, byInt64Wrapper, "Sort") }
 func BenchmarkSort1e6(b *testing.B) { bench(b, 1e6, byInt64Wrapper, "Sort") }
@@ -0,0 +1,49 @@
+// Copyright 2015 Randall Farmer. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sorts_test
+
+import (
+	"math/rand"
+	"testing"
+
+	. "github.com/twotwotwo/sorts"
+	. "github.com/twotwotwo/sorts/sortutil"
+)
+
+func TestSortRange(t *testing.T) {
+	n := 50000
+	orig := make([]int, n)
+	for i := range orig {
+		orig[i] = rand.Intn(n)
+	}
+	ranges := [][2]int{{0, 0}, {0, n}, {10, 20}, {100, n - 100}, {n / 2, n}, {n - 1, n}}
+	for _, r := range ranges {
+		a, b := r[0], r[1]
+		varyQSortCutoff(func() {
+			ints := append([]int(nil), orig...)
+			bytes, strings, uints := convertInts(ints)
+			ByInt64Range(IntSlice(ints), a, b)
+			ByUint64Range(UintSlice(uints), a, b)
+			ByStringRange(StringSlice(strings), a, b)
+			ByBytesRange(BytesSlice(bytes), a, b)
+			if !IntsAreSorted(ints[a:b]) || !UintsAreSorted(uints[a:b]) ||
+				!StringsAreSorted(strings[a:b]) || !BytesAreSorted(bytes[a:b]) {
+				t.Fatalf("range [%d,%d) didn't sort", a, b)
+			}
+			for i := range ints {
+				if (i < a || i >= b) && (ints[i] != orig[i] || int(uints[i]) != orig[i]) {
+					t.Fatalf("sorting [%d,%d) changed item %d", a, b, i)
+				}
+			}
+		})
+	}
+
+	mustPanic(t, "out-of-bounds range", func() {
+		ByUint64Range(UintSlice(make([]uint, 10)), 5, 11)
+	})
+	mustPanic(t, "backwards range", func() {
+		ByStringRange(StringSlice(make([]string, 10)), 5, 4)
+	})
+}
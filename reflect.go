@@ -0,0 +1,93 @@
+// Copyright 2015 Randall Farmer. All rights reserved.
+
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+package sorts
+
+import (
+	"reflect"
+
+	"github.com/twotwotwo/sorts/internal/floatkey"
+)
+
+// SortSlice is a stand-in for sort.Slice: it sorts slice, which must be a
+// slice, using less, except that slices of integers or floats (including
+// named types like time.Duration) are radix sorted in increasing order,
+// and less is ignored.  Floats are in sortutil.Float64Key's order: NaNs
+// with the sign bit clear, like math.NaN(), go last, and those with it set
+// go first.  Other slices are quicksorted with less in the calling
+// goroutine, as sort.Slice would sort them.  Reflection is only used to
+// pick a path and, for named element types, to read the keys; plain []int
+// and the like go straight to Slice.
+func SortSlice(slice interface{}, less func(i, j int) bool) {
+	switch a := slice.(type) {
+	case []int:
+		Slice(a)
+	case []int32:
+		Slice(a)
+	case []int64:
+		Slice(a)
+	case []uint:
+		Slice(a)
+	case []uint32:
+		Slice(a)
+	case []uint64:
+		Slice(a)
+	case []float32:
+		Slice(a)
+	case []float64:
+		Slice(a)
+	default:
+		v := reflect.ValueOf(slice)
+		r := reflectSlice{v, reflect.Swapper(slice)}
+		switch v.Type().Elem().Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			ByInt64(reflectInts{r})
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			ByUint64(reflectUints{r})
+		case reflect.Float32, reflect.Float64:
+			ByUint64(reflectFloats{r})
+		default:
+			// reflect.Swapper swaps structs and such through one shared
+			// temporary, so their swaps can't run in parallel
+			qSort(lessSwap{v.Len(), less, r.swap}, 0, v.Len())
+		}
+	}
+}
+
+// reflectSlice is the part of the reflect-based types SortSlice uses that
+// doesn't depend on the element kind.
+type reflectSlice struct {
+	v    reflect.Value
+	swap func(i, j int)
+}
+
+func (r reflectSlice) Len() int      { return r.v.Len() }
+func (r reflectSlice) Swap(i, j int) { r.swap(i, j) }
+
+type reflectInts struct{ reflectSlice }
+
+func (r reflectInts) Less(i, j int) bool { return r.Key(i) < r.Key(j) }
+func (r reflectInts) Key(i int) int64    { return r.v.Index(i).Int() }
+
+type reflectUints struct{ reflectSlice }
+
+func (r reflectUints) Less(i, j int) bool { return r.Key(i) < r.Key(j) }
+func (r reflectUints) Key(i int) uint64   { return r.v.Index(i).Uint() }
+
+type reflectFloats struct{ reflectSlice }
+
+func (r reflectFloats) Less(i, j int) bool { return r.Key(i) < r.Key(j) }
+func (r reflectFloats) Key(i int) uint64   { return floatkey.Float64(r.v.Index(i).Float()) }
+
+// lessSwap is a sort.Interface made of funcs, as sort.Slice uses.
+type lessSwap struct {
+	n    int
+	less func(i, j int) bool
+	swap func(i, j int)
+}
+
+func (l lessSwap) Len() int           { return l.n }
+func (l lessSwap) Less(i, j int) bool { return l.less(i, j) }
+func (l lessSwap) Swap(i, j int)      { l.swap(i, j) }
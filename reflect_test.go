@@ -0,0 +1,76 @@
+// Copyright 2015 Randall Farmer. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sorts_test
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+	"testing"
+	"time"
+
+	. "github.com/twotwotwo/sorts"
+)
+
+type celsius float32
+
+func TestSortSlice(t *testing.T) {
+	n := 10000
+	ints := make([]int, n)
+	durations := make([]time.Duration, n)
+	temps := make([]celsius, n)
+	bytes := make([]uint8, n)
+	for i := 0; i < n; i++ {
+		ints[i] = rand.Int() - rand.Int()
+		durations[i] = time.Duration(rand.Int63n(2e9) - 1e9)
+		temps[i] = celsius(rand.NormFloat64() * 20)
+		bytes[i] = uint8(rand.Intn(256))
+	}
+	temps[0] = celsius(math.NaN())
+	never := func(i, j int) bool { panic("radix path called less") }
+
+	SortSlice(ints, never)
+	SortSlice(durations, never)
+	SortSlice(temps, never)
+	SortSlice(bytes, never)
+	if !sort.IntsAreSorted(ints) {
+		t.Errorf("ints not sorted")
+	}
+	if !sort.SliceIsSorted(durations, func(i, j int) bool { return durations[i] < durations[j] }) {
+		t.Errorf("durations not sorted")
+	}
+	if !math.IsNaN(float64(temps[n-1])) || !sort.SliceIsSorted(temps[:n-1], func(i, j int) bool { return temps[i] < temps[j] }) {
+		t.Errorf("temperatures not sorted with NaN last")
+	}
+	if !sort.SliceIsSorted(bytes, func(i, j int) bool { return bytes[i] < bytes[j] }) {
+		t.Errorf("bytes not sorted")
+	}
+
+	type person struct {
+		name string
+		age  int
+	}
+	people := []person{{"Bo", 40}, {"Al", 30}, {"Cy", 20}}
+	SortSlice(people, func(i, j int) bool { return people[i].age < people[j].age })
+	if people[0].name != "Cy" || people[2].name != "Bo" {
+		t.Errorf("people sorted to %v", people)
+	}
+}
+
+// TestSortSliceStructs sorts enough structs for a parallel sort, so with
+// -cpu above 1 the race detector catches swaps running concurrently.
+func TestSortSliceStructs(t *testing.T) {
+	type point struct{ x, y, z int }
+	points := make([]point, 50000)
+	for i := range points {
+		points[i] = point{rand.Int(), i, -i}
+	}
+	SortSlice(points, func(i, j int) bool { return points[i].x < points[j].x })
+	for i, p := range points {
+		if p.z != -p.y || i > 0 && points[i-1].x > p.x {
+			t.Fatalf("points not sorted, or corrupted at %d: %v", i, p)
+		}
+	}
+}
@@ -0,0 +1,29 @@
+// Copyright 2015 Randall Farmer. All rights reserved.
+
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+package sorts
+
+// Reverse is sort.Reverse for a Uint64Interface: it reverses Less and
+// complements Key, so ByUint64(Reverse(data)) radix sorts data in
+// decreasing order.
+//
+// There's no key that reverses string order, so there's no Reverse for
+// StringInterface or BytesInterface.  Either sort.Reverse the data for a
+// comparison sort like Quicksort or sort.Sort, or radix sort it with
+// ByString or ByBytes and then Flip it.
+func Reverse(data Uint64Interface) Uint64Interface { return reverseUint64{data} }
+
+type reverseUint64 struct{ Uint64Interface }
+
+func (r reverseUint64) Less(i, j int) bool { return r.Uint64Interface.Less(j, i) }
+func (r reverseUint64) Key(i int) uint64   { return ^r.Uint64Interface.Key(i) }
+
+// ReverseInt64 is Reverse for an Int64Interface.
+func ReverseInt64(data Int64Interface) Int64Interface { return reverseInt64{data} }
+
+type reverseInt64 struct{ Int64Interface }
+
+func (r reverseInt64) Less(i, j int) bool { return r.Int64Interface.Less(j, i) }
+func (r reverseInt64) Key(i int) int64    { return ^r.Int64Interface.Key(i) }
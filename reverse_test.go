@@ -0,0 +1,38 @@
+// Copyright 2015 Randall Farmer. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sorts_test
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+
+	. "github.com/twotwotwo/sorts"
+	. "github.com/twotwotwo/sorts/sortutil"
+)
+
+func TestReverse(t *testing.T) {
+	uints := make([]uint64, 10000)
+	ints := make([]int64, len(uints))
+	strs := make([]string, len(uints))
+	for i := range uints {
+		uints[i] = uint64(rand.Int63n(1000))
+		ints[i] = rand.Int63n(1000) - 500
+		strs[i] = string(rune('a' + rand.Intn(26)))
+	}
+	ints[0], ints[1] = -1<<63, 1<<63-1
+	ByUint64(Reverse(Uint64Slice(uints)))
+	if !sort.IsSorted(sort.Reverse(Uint64Slice(uints))) {
+		t.Errorf("Reverse didn't sort uint64s in decreasing order")
+	}
+	ByInt64(ReverseInt64(Int64Slice(ints)))
+	if !sort.IsSorted(sort.Reverse(Int64Slice(ints))) || ints[0] != 1<<63-1 {
+		t.Errorf("ReverseInt64 didn't sort int64s in decreasing order")
+	}
+	Quicksort(sort.Reverse(StringSlice(strs)))
+	if !sort.IsSorted(sort.Reverse(StringSlice(strs))) {
+		t.Errorf("Quicksort of sort.Reverse didn't sort strings in decreasing order")
+	}
+}
@@ -0,0 +1,80 @@
+// Copyright 2015 Randall Farmer. All rights reserved.
+
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+package sorts
+
+import "sort"
+
+// Scratch holds the count tables a string or []byte radix sort needs, one
+// set per level of recursion, so a goroutine sorting batch after batch can
+// reuse them.  Sorts using a Scratch run in the calling goroutine and
+// allocate nothing once the Scratch has grown to fit; a parallel sort
+// starts goroutines and allocates for each task it hands off.  The zero
+// value is ready to use.  A Scratch mustn't be used by two sorts at once,
+// so use one per goroutine.
+type Scratch struct {
+	tables    []*bucketTables
+	data      sort.Interface
+	sorter    func(sort.Interface, task, func(task), *bucketTables)
+	sortRange func(task) // s.sortTask, kept so it's only allocated once
+}
+
+// ByStringWithScratch is ByString using s's tables, without goroutines.
+func ByStringWithScratch(data StringInterface, s *Scratch) {
+	a, b := 0, data.Len()
+	if b < 2 {
+		return
+	}
+	if b < qSortCutoff {
+		qSort(data, a, b)
+		return
+	}
+	if !stringPresorted(data, a, b) {
+		s.sort(data, radixSortStringTables, task{offs: 0, pos: a, end: b})
+	}
+	checkString(data, a, b)
+}
+
+// ByBytesWithScratch is ByBytes using s's tables, without goroutines.
+func ByBytesWithScratch(data BytesInterface, s *Scratch) {
+	a, b := 0, data.Len()
+	if b < 2 {
+		return
+	}
+	if b < qSortCutoff {
+		qSort(data, a, b)
+		return
+	}
+	if !bytesPresorted(data, a, b) {
+		s.sort(data, radixSortBytesTables, task{offs: 0, pos: a, end: b})
+	}
+	checkBytes(data, a, b)
+}
+
+// sort runs sorter on t and its subtasks in this goroutine.
+func (s *Scratch) sort(data sort.Interface, sorter func(sort.Interface, task, func(task), *bucketTables), t task) {
+	if s.sortRange == nil {
+		s.sortRange = s.sortTask
+	}
+	s.data, s.sorter = data, sorter
+	s.sortRange(t)
+	s.data, s.sorter = nil, nil
+}
+
+// sortTask runs s.sorter on t with zeroed tables for t's depth.  Subtasks
+// are one level deeper than their parent, so the tables of a task still
+// in progress aren't reused until it's done.  Quicksort tasks (negative
+// offs) don't use tables.
+func (s *Scratch) sortTask(t task) {
+	var tbl *bucketTables
+	if t.offs >= 0 {
+		for len(s.tables) <= t.depth {
+			s.tables = append(s.tables, new(bucketTables))
+		}
+		tbl = s.tables[t.depth]
+		*tbl = bucketTables{}
+	}
+	s.sorter(s.data, t, s.sortRange, tbl)
+}
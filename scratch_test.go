@@ -0,0 +1,46 @@
+// Copyright 2015 Randall Farmer. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sorts_test
+
+import (
+	"math/rand"
+	"strconv"
+	"strings"
+	"testing"
+
+	. "github.com/twotwotwo/sorts"
+	. "github.com/twotwotwo/sorts/sortutil"
+)
+
+func TestScratch(t *testing.T) {
+	var s Scratch
+	for _, n := range []int{0, 1, 10, 1000, 100000} {
+		a := make([]string, n)
+		b := make([][]byte, n)
+		for i := range a {
+			a[i] = strings.Repeat("x", rand.Intn(20)) + strconv.Itoa(rand.Intn(n))
+			b[i] = []byte(a[i])
+		}
+		ByStringWithScratch(StringSlice(a), &s)
+		ByBytesWithScratch(BytesSlice(b), &s)
+		if !StringsAreSorted(a) || !BytesAreSorted(b) {
+			t.Errorf("n=%d: didn't sort", n)
+		}
+	}
+
+	orig := make([]string, 10000)
+	for i := range orig {
+		orig[i] = strconv.Itoa(rand.Int())
+	}
+	a := make([]string, len(orig))
+	var data StringInterface = StringSlice(a)
+	allocs := testing.AllocsPerRun(10, func() {
+		copy(a, orig)
+		ByStringWithScratch(data, &s)
+	})
+	if allocs != 0 {
+		t.Errorf("ByStringWithScratch made %v allocations", allocs)
+	}
+}
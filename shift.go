@@ -0,0 +1,63 @@
+// Copyright 2015 Randall Farmer. All rights reserved.
+
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+package sorts
+
+// EstimateShift returns the bit shift ByUint64 would start radix sorting
+// data at, for passing to ByUint64Shift when sorting many collections
+// with the same range of keys.  It samples about 256 keys.
+func EstimateShift(data Uint64Interface) uint {
+	return guessIntShift(data, 0, data.Len())
+}
+
+// EstimateInt64Shift is EstimateShift for ByInt64Shift.
+func EstimateInt64Shift(data Int64Interface) uint {
+	return guessIntShift(intwrapper{data}, 0, data.Len())
+}
+
+// ByUint64Shift sorts data like ByUint64, but starts radix sorting at the
+// given shift instead of estimating one: the first pass buckets by bits
+// shift to shift+7 of the keys.  For keys known to be under 1<<24, that's
+// 16.  Any shift sorts correctly, but one too low costs a wasted pass when
+// higher bits turn out to vary, and one too high costs a pass that finds
+// them all equal.  Shifts over 56 are treated as 56.
+func ByUint64Shift(data Uint64Interface, shift uint) {
+	l := data.Len()
+	if l < qSortCutoff {
+		qSortUint64(data, 0, l)
+		return
+	}
+	if !uint64Presorted(data, 0, l) {
+		parallelSort(data, radixSortUint64, task{offs: clampShift(shift), pos: 0, end: l})
+	}
+
+	// check results!
+	checkUint64(data, 0, l)
+}
+
+// ByInt64Shift is ByUint64Shift for int64 keys, where the shift applies to
+// the key with its sign bit flipped, as EstimateInt64Shift returns it.
+func ByInt64Shift(data Int64Interface, shift uint) {
+	l := data.Len()
+	if l < qSortCutoff {
+		qSortInt64(data, 0, l)
+		return
+	}
+	if !int64Presorted(data, 0, l) {
+		parallelSort(data, radixSortInt64, task{offs: clampShift(shift), pos: 0, end: l})
+	}
+
+	// check results!
+	checkInt64(data, 0, l)
+}
+
+// clampShift limits shift to the highest one whose bucket bits are all
+// inside a uint64.
+func clampShift(shift uint) int {
+	if shift > 64-radix {
+		return 64 - radix
+	}
+	return int(shift)
+}
@@ -0,0 +1,67 @@
+// Copyright 2015 Randall Farmer. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sorts_test
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+
+	. "github.com/twotwotwo/sorts"
+	. "github.com/twotwotwo/sorts/sortutil"
+)
+
+func TestByUint64Shift(t *testing.T) {
+	a := make([]uint64, 10000)
+	for i := range a {
+		a[i] = uint64(rand.Intn(1 << 24))
+	}
+	if s := EstimateShift(Uint64Slice(a)); s != 16 {
+		t.Errorf("EstimateShift of 24-bit keys is %d, want 16", s)
+	}
+	orig := append([]uint64(nil), a...)
+	for _, shift := range []uint{0, 5, 16, 40, 56, 100} {
+		copy(a, orig)
+		ByUint64Shift(Uint64Slice(a), shift)
+		if !Uint64sAreSorted(a) {
+			t.Errorf("shift %d: not sorted", shift)
+		}
+	}
+
+	ints := make([]int, 10000)
+	for i := range ints {
+		ints[i] = rand.Intn(1<<20) - 1<<19
+	}
+	ByInt64Shift(IntSlice(ints), EstimateInt64Shift(IntSlice(ints)))
+	if !sort.IntsAreSorted(ints) {
+		t.Errorf("ints not sorted")
+	}
+}
+
+// TestMisalignedShift starts the sort at every shift, most of them not
+// multiples of the radix, on keys that vary in every bit, so bits below
+// or above the starting shift going unexamined would leave them unsorted.
+func TestMisalignedShift(t *testing.T) {
+	orig := make([]uint64, 2000)
+	for i := range orig {
+		orig[i] = rand.Uint64()
+		if i%2 == 0 {
+			orig[i] &= 0x1ff // vary only in low bits, for deep recursion
+		}
+	}
+	a := make([]uint64, len(orig))
+	ints := make([]int64, len(orig))
+	for shift := uint(0); shift <= 64; shift++ {
+		copy(a, orig)
+		for i, v := range orig {
+			ints[i] = int64(v)
+		}
+		ByUint64Shift(Uint64Slice(a), shift)
+		ByInt64Shift(Int64Slice(ints), shift)
+		if !Uint64sAreSorted(a) || !Int64sAreSorted(ints) {
+			t.Errorf("shift %d: not sorted", shift)
+		}
+	}
+}
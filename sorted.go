@@ -0,0 +1,80 @@
+// Copyright 2015 Randall Farmer. All rights reserved.
+
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+package sorts
+
+import "bytes"
+
+// Uint64KeysSorted reports whether data is in increasing order by Key,
+// calling Key once per item and never calling Less.  That's cheaper than
+// sort.IsSorted when Less calls Key twice, and it checks the order the
+// radix sort actually produces, so it catches a Key that disagrees with
+// Less where sort.IsSorted wouldn't.
+func Uint64KeysSorted(data Uint64Interface) bool {
+	l := data.Len()
+	if l == 0 {
+		return true
+	}
+	prev := data.Key(0)
+	for i := 1; i < l; i++ {
+		k := data.Key(i)
+		if k < prev {
+			return false
+		}
+		prev = k
+	}
+	return true
+}
+
+// Int64KeysSorted is Uint64KeysSorted for int64 keys.
+func Int64KeysSorted(data Int64Interface) bool {
+	l := data.Len()
+	if l == 0 {
+		return true
+	}
+	prev := data.Key(0)
+	for i := 1; i < l; i++ {
+		k := data.Key(i)
+		if k < prev {
+			return false
+		}
+		prev = k
+	}
+	return true
+}
+
+// StringKeysSorted is Uint64KeysSorted for string keys.
+func StringKeysSorted(data StringInterface) bool {
+	l := data.Len()
+	if l == 0 {
+		return true
+	}
+	prev := data.Key(0)
+	for i := 1; i < l; i++ {
+		k := data.Key(i)
+		if k < prev {
+			return false
+		}
+		prev = k
+	}
+	return true
+}
+
+// BytesKeysSorted is Uint64KeysSorted for []byte keys.
+func BytesKeysSorted(data BytesInterface) bool {
+	l := data.Len()
+	if l == 0 {
+		return true
+	}
+	prev := data.Key(0)
+	for i := 1; i < l; i++ {
+		k := data.Key(i)
+		if bytes.Compare(k, prev) < 0 {
+			return false
+		}
+		prev = k
+	}
+	return true
+}
@@ -0,0 +1,43 @@
+// Copyright 2015 Randall Farmer. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sorts_test
+
+import (
+	"math"
+	"sort"
+	"testing"
+
+	. "github.com/twotwotwo/sorts"
+	. "github.com/twotwotwo/sorts/sortutil"
+)
+
+// naiveFloats uses < for Less but Float64Key for Key, so a NaN looks
+// sorted anywhere to sort.IsSorted.
+type naiveFloats struct{ Float64Slice }
+
+func (p naiveFloats) Less(i, j int) bool { return p.Float64Slice[i] < p.Float64Slice[j] }
+
+func TestKeysSorted(t *testing.T) {
+	if !Uint64KeysSorted(Uint64Slice(nil)) || !StringKeysSorted(StringSlice{"a"}) {
+		t.Errorf("empty or one-item data isn't sorted")
+	}
+	if !Int64KeysSorted(Int64Slice{-5, -5, 0, 3}) || Int64KeysSorted(Int64Slice{0, -1}) {
+		t.Errorf("Int64KeysSorted wrong")
+	}
+	if !StringKeysSorted(StringSlice{"", "a", "ab", "b"}) || StringKeysSorted(StringSlice{"b", "ab"}) {
+		t.Errorf("StringKeysSorted wrong")
+	}
+	if !BytesKeysSorted(BytesSlice{nil, []byte("a")}) || BytesKeysSorted(BytesSlice{[]byte("a"), nil}) {
+		t.Errorf("BytesKeysSorted wrong")
+	}
+
+	data := naiveFloats{Float64Slice{1, math.NaN(), 2}}
+	if !sort.IsSorted(data) {
+		t.Fatalf("expected sort.IsSorted to miss the misplaced NaN")
+	}
+	if Uint64KeysSorted(data) {
+		t.Errorf("Uint64KeysSorted missed the misplaced NaN")
+	}
+}
@@ -0,0 +1,216 @@
+// Copyright 2015 Randall Farmer. All rights reserved.
+
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+package sortutil
+
+import "github.com/twotwotwo/sorts"
+
+// argsorter sorts a copy of some keys along with their original positions.
+// Equal keys stay in their original order.
+type argsorter struct {
+	keys []uint64
+	perm []int
+}
+
+func (a argsorter) Len() int { return len(a.keys) }
+func (a argsorter) Less(i, j int) bool {
+	return a.keys[i] < a.keys[j] || (a.keys[i] == a.keys[j] && a.perm[i] < a.perm[j])
+}
+func (a argsorter) Swap(i, j int) {
+	a.keys[i], a.keys[j] = a.keys[j], a.keys[i]
+	a.perm[i], a.perm[j] = a.perm[j], a.perm[i]
+}
+func (a argsorter) Key(i int) uint64 { return a.keys[i] }
+
+// argsort sorts keys, which it owns, and returns where each came from.
+func argsort(keys []uint64) []int {
+	a := argsorter{keys, identity(len(keys))}
+	sorts.ByUint64(a)
+	return a.perm
+}
+
+// ArgsortUint64 returns the permutation p that sorts keys, so keys[p[0]] <=
+// keys[p[1]] <= ..., without modifying keys.  Equal keys appear in their
+// original order.  It's useful when items are expensive to swap, or several
+// parallel slices need the same order.  It uses 16 bytes of scratch space
+// per item on 64-bit platforms.
+func ArgsortUint64(keys []uint64) []int {
+	return argsort(append([]uint64(nil), keys...))
+}
+
+// ArgsortInt64 is ArgsortUint64 for int64s.
+func ArgsortInt64(keys []int64) []int {
+	k := make([]uint64, len(keys))
+	for i, v := range keys {
+		k[i] = uint64(v) ^ 1<<63
+	}
+	return argsort(k)
+}
+
+// ArgsortFloat64 is ArgsortUint64 for float64s, in Float64Key's order:
+// NaNs with the sign bit clear, like math.NaN(), go last, and those with it
+// set go first.
+func ArgsortFloat64(keys []float64) []int {
+	k := make([]uint64, len(keys))
+	for i, v := range keys {
+		k[i] = Float64Key(v)
+	}
+	return argsort(k)
+}
+
+// stringArgsorter is argsorter for strings.
+type stringArgsorter struct {
+	keys []string
+	perm []int
+}
+
+func (a stringArgsorter) Len() int { return len(a.keys) }
+func (a stringArgsorter) Less(i, j int) bool {
+	return a.keys[i] < a.keys[j] || (a.keys[i] == a.keys[j] && a.perm[i] < a.perm[j])
+}
+func (a stringArgsorter) Swap(i, j int) {
+	a.keys[i], a.keys[j] = a.keys[j], a.keys[i]
+	a.perm[i], a.perm[j] = a.perm[j], a.perm[i]
+}
+func (a stringArgsorter) Key(i int) string { return a.keys[i] }
+
+// ArgsortString is ArgsortUint64 for strings.  It copies the string
+// headers, not their contents.
+func ArgsortString(keys []string) []int {
+	a := stringArgsorter{append([]string(nil), keys...), identity(len(keys))}
+	sorts.ByString(a)
+	return a.perm
+}
+
+// keySwapper sorts keys, mirroring each swap through swap.
+type keySwapper struct {
+	keys []uint64
+	swap func(i, j int)
+}
+
+func (k keySwapper) Len() int           { return len(k.keys) }
+func (k keySwapper) Less(i, j int) bool { return k.keys[i] < k.keys[j] }
+func (k keySwapper) Swap(i, j int) {
+	k.keys[i], k.keys[j] = k.keys[j], k.keys[i]
+	k.swap(i, j)
+}
+func (k keySwapper) Key(i int) uint64 { return k.keys[i] }
+
+// SortByKeys sorts keys in place, calling swap(i, j) for every swap it
+// does so another slice, say of payloads, can be kept in step with keys.
+// Swaps can happen from several goroutines at once, always on different
+// items, as they would with sorts.ByUint64, so swap shouldn't touch
+// anything but the items at i and j.  Items with equal keys end up in no
+// particular order.
+func SortByKeys(keys []uint64, swap func(i, j int)) {
+	sorts.ByUint64(keySwapper{keys, swap})
+}
+
+// The SortXsWithPerm funcs sort a slice in place like Ints and the rest,
+// and also return perm, where perm[j] is the position the item now at j
+// started at: Argsort's result, without a second pass to apply it.  Equal
+// items stay in their original order.  perm is the only allocation.
+
+// permSorter sorts a and perm together, ordered by a, then perm.
+type permSorter[T int | int64 | uint64 | string] struct {
+	a    []T
+	perm []int
+}
+
+func newPermSorter[T int | int64 | uint64 | string](a []T) permSorter[T] {
+	return permSorter[T]{a, identity(len(a))}
+}
+
+// identity returns the permutation of n items that leaves them in place.
+func identity(n int) []int {
+	perm := make([]int, n)
+	for i := range perm {
+		perm[i] = i
+	}
+	return perm
+}
+
+func (p permSorter[T]) Len() int { return len(p.a) }
+func (p permSorter[T]) Less(i, j int) bool {
+	return p.a[i] < p.a[j] || (p.a[i] == p.a[j] && p.perm[i] < p.perm[j])
+}
+func (p permSorter[T]) Swap(i, j int) {
+	p.a[i], p.a[j] = p.a[j], p.a[i]
+	p.perm[i], p.perm[j] = p.perm[j], p.perm[i]
+}
+
+type intsPerm struct{ permSorter[int] }
+
+func (p intsPerm) Key(i int) int64 { return int64(p.a[i]) }
+
+type int64sPerm struct{ permSorter[int64] }
+
+func (p int64sPerm) Key(i int) int64 { return p.a[i] }
+
+type uint64sPerm struct{ permSorter[uint64] }
+
+func (p uint64sPerm) Key(i int) uint64 { return p.a[i] }
+
+type stringsPerm struct{ permSorter[string] }
+
+func (p stringsPerm) Key(i int) string { return p.a[i] }
+
+// float64sPerm is permSorter for float64s, ordered by Float64Key.
+type float64sPerm struct {
+	a    []float64
+	perm []int
+}
+
+func (p float64sPerm) Len() int { return len(p.a) }
+func (p float64sPerm) Less(i, j int) bool {
+	ki, kj := Float64Key(p.a[i]), Float64Key(p.a[j])
+	return ki < kj || (ki == kj && p.perm[i] < p.perm[j])
+}
+func (p float64sPerm) Swap(i, j int) {
+	p.a[i], p.a[j] = p.a[j], p.a[i]
+	p.perm[i], p.perm[j] = p.perm[j], p.perm[i]
+}
+func (p float64sPerm) Key(i int) uint64 { return Float64Key(p.a[i]) }
+
+// SortIntsWithPerm sorts a in increasing order and returns where each
+// item came from.
+func SortIntsWithPerm(a []int) (perm []int) {
+	p := intsPerm{newPermSorter(a)}
+	sorts.ByInt64(p)
+	return p.perm
+}
+
+// SortInt64sWithPerm sorts a in increasing order and returns where each
+// item came from.
+func SortInt64sWithPerm(a []int64) (perm []int) {
+	p := int64sPerm{newPermSorter(a)}
+	sorts.ByInt64(p)
+	return p.perm
+}
+
+// SortUint64sWithPerm sorts a in increasing order and returns where each
+// item came from.
+func SortUint64sWithPerm(a []uint64) (perm []int) {
+	p := uint64sPerm{newPermSorter(a)}
+	sorts.ByUint64(p)
+	return p.perm
+}
+
+// SortFloat64sWithPerm sorts a in increasing order and returns where each
+// item came from.  As with Float64Key, NaNs with the sign bit clear go
+// last and those with it set go first.
+func SortFloat64sWithPerm(a []float64) (perm []int) {
+	p := float64sPerm{a, identity(len(a))}
+	sorts.ByUint64(p)
+	return p.perm
+}
+
+// SortStringsWithPerm sorts a in increasing order and returns where each
+// item came from.
+func SortStringsWithPerm(a []string) (perm []int) {
+	p := stringsPerm{newPermSorter(a)}
+	sorts.ByString(p)
+	return p.perm
+}
@@ -0,0 +1,125 @@
+// Copyright 2015 Randall Farmer. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sortutil_test
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	. "github.com/twotwotwo/sorts/sortutil"
+)
+
+// checkPerm checks that p is a permutation putting n items in order by
+// less, with equal items in their original order.
+func checkPerm(t *testing.T, name string, n int, p []int, less func(i, j int) bool) {
+	if len(p) != n {
+		t.Fatalf("%s: got %d positions for %d items", name, len(p), n)
+	}
+	seen := make([]bool, n)
+	for i, j := range p {
+		if j < 0 || j >= n || seen[j] {
+			t.Fatalf("%s: not a permutation: %v", name, p)
+		}
+		seen[j] = true
+		if i > 0 && (less(j, p[i-1]) || (!less(p[i-1], j) && p[i-1] > j)) {
+			t.Fatalf("%s: items at %d and %d out of order", name, p[i-1], j)
+		}
+	}
+}
+
+func TestArgsort(t *testing.T) {
+	u := make([]uint64, testSize)
+	s := make([]int64, testSize)
+	f := make([]float64, testSize)
+	strs := make([]string, testSize)
+	for i := range u {
+		u[i] = uint64(rand.Intn(100))
+		s[i] = int64(rand.Intn(100)) - 50
+		f[i] = float64s[i%len(float64s)]
+		strs[i] = strings[i%len(strings)]
+	}
+	uCopy := append([]uint64(nil), u...)
+	checkPerm(t, "ArgsortUint64", len(u), ArgsortUint64(u), func(i, j int) bool { return u[i] < u[j] })
+	for i := range u {
+		if u[i] != uCopy[i] {
+			t.Fatalf("ArgsortUint64 modified its input")
+		}
+	}
+	checkPerm(t, "ArgsortInt64", len(s), ArgsortInt64(s), func(i, j int) bool { return s[i] < s[j] })
+	checkPerm(t, "ArgsortFloat64", len(f), ArgsortFloat64(f), func(i, j int) bool {
+		return f[i] < f[j] || (!math.IsNaN(f[i]) && math.IsNaN(f[j]))
+	})
+	checkPerm(t, "ArgsortString", len(strs), ArgsortString(strs), func(i, j int) bool { return strs[i] < strs[j] })
+	if p := ArgsortUint64(nil); len(p) != 0 {
+		t.Errorf("ArgsortUint64(nil) returned %v", p)
+	}
+}
+
+func TestSortByKeys(t *testing.T) {
+	keys := make([]uint64, testSize*10)
+	payloads := make([]uint64, len(keys))
+	for i := range keys {
+		keys[i] = uint64(rand.Intn(1000)) << 32
+		payloads[i] = keys[i] | uint64(i)
+	}
+	SortByKeys(keys, func(i, j int) { payloads[i], payloads[j] = payloads[j], payloads[i] })
+	if !Uint64sAreSorted(keys) {
+		t.Errorf("SortByKeys didn't sort keys")
+	}
+	for i := range keys {
+		if payloads[i]>>32<<32 != keys[i] {
+			t.Fatalf("payload %d is %#x, out of step with key %#x", i, payloads[i], keys[i])
+		}
+	}
+}
+
+func TestSortWithPerm(t *testing.T) {
+	a := make([]int, testSize)
+	f := make([]float64, testSize)
+	s := make([]string, testSize)
+	for i := range a {
+		a[i] = rand.Intn(100) - 50
+		f[i] = float64s[i%len(float64s)]
+		s[i] = strings[i%len(strings)]
+	}
+	origA := append([]int(nil), a...)
+	perm := SortIntsWithPerm(a)
+	checkPerm(t, "SortIntsWithPerm", len(a), perm, func(i, j int) bool { return origA[i] < origA[j] })
+	for j, i := range perm {
+		if a[j] != origA[i] {
+			t.Fatalf("SortIntsWithPerm: a[%d] is %d, but it came from %d, which was %d", j, a[j], i, origA[i])
+		}
+	}
+
+	origF := append([]float64(nil), f...)
+	perm = SortFloat64sWithPerm(f)
+	checkPerm(t, "SortFloat64sWithPerm", len(f), perm, func(i, j int) bool {
+		return origF[i] < origF[j] || (!math.IsNaN(origF[i]) && math.IsNaN(origF[j]))
+	})
+	for j, i := range perm {
+		if Float64Key(f[j]) != Float64Key(origF[i]) {
+			t.Fatalf("SortFloat64sWithPerm: f[%d] didn't come from %d", j, i)
+		}
+	}
+
+	origS := append([]string(nil), s...)
+	perm = SortStringsWithPerm(s)
+	checkPerm(t, "SortStringsWithPerm", len(s), perm, func(i, j int) bool { return origS[i] < origS[j] })
+	for j, i := range perm {
+		if s[j] != origS[i] {
+			t.Fatalf("SortStringsWithPerm: s[%d] didn't come from %d", j, i)
+		}
+	}
+
+	i64 := []int64{3, -1, 2}
+	if perm := SortInt64sWithPerm(i64); perm[0] != 1 || perm[1] != 2 || perm[2] != 0 {
+		t.Errorf("SortInt64sWithPerm returned %v", perm)
+	}
+	u64 := []uint64{3, 1, 2}
+	if perm := SortUint64sWithPerm(u64); perm[0] != 1 || perm[1] != 2 || perm[2] != 0 {
+		t.Errorf("SortUint64sWithPerm returned %v", perm)
+	}
+}
@@ -0,0 +1,50 @@
+// Copyright 2015 Randall Farmer. All rights reserved.
+
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+package sortutil
+
+import (
+	"bytes"
+	"sort"
+
+	"github.com/twotwotwo/sorts"
+)
+
+// Array16Slice attaches the methods of sorts.FixedBytesInterface to
+// [][16]byte, for things like MD5 sums and UUIDs, sorting in increasing
+// order.  Keys point into the slice itself, so unlike a BytesSlice it
+// needs no separate slice header or allocation per item, and sorting
+// radix sorts all 16 bytes without comparing keys.
+type Array16Slice [][16]byte
+
+func (p Array16Slice) Len() int           { return len(p) }
+func (p Array16Slice) Less(i, j int) bool { return bytes.Compare(p[i][:], p[j][:]) < 0 }
+func (p Array16Slice) Swap(i, j int)      { p[i], p[j] = p[j], p[i] }
+
+// Key returns item i as a []byte pointing into p.
+func (p Array16Slice) Key(i int) []byte { return p[i][:] }
+
+// KeyLen returns 16.
+func (p Array16Slice) KeyLen() int { return 16 }
+
+// Sort is a convenience method.
+func (p Array16Slice) Sort() { sorts.ByFixedBytes(p) }
+
+// Arrays16 sorts a slice of [16]byte in increasing order.
+func Arrays16(a [][16]byte) { Array16Slice(a).Sort() }
+
+// Arrays16AreSorted tests whether a slice of [16]byte is sorted in
+// increasing order.
+func Arrays16AreSorted(a [][16]byte) bool { return sort.IsSorted(Array16Slice(a)) }
+
+// SearchArrays16 finds the first array >= x; read about sort.Search for
+// more.
+func SearchArrays16(a [][16]byte, x [16]byte) int {
+	return sort.Search(len(a), func(i int) bool { return bytes.Compare(a[i][:], x[:]) >= 0 })
+}
+
+// Search returns the result of applying SearchArrays16 to the receiver and
+// x.
+func (p Array16Slice) Search(x [16]byte) int { return SearchArrays16(p, x) }
@@ -0,0 +1,58 @@
+// Copyright 2015 Randall Farmer. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sortutil_test
+
+import (
+	"crypto/md5"
+	"math/rand"
+	"strconv"
+	"testing"
+
+	. "github.com/twotwotwo/sorts/sortutil"
+)
+
+func TestArray16Slice(t *testing.T) {
+	a := make(Array16Slice, testSize)
+	for i := range a {
+		a[i] = md5.Sum([]byte(strconv.Itoa(i % 100)))
+	}
+	a.Sort()
+	if !Arrays16AreSorted(a) {
+		t.Errorf("arrays didn't sort")
+	}
+	x := md5.Sum([]byte("42"))
+	i := a.Search(x)
+	if a[i] != x || i > 0 && a[i-1] == x {
+		t.Errorf("search for %x found %d", x, i)
+	}
+	if SearchArrays16(a, [16]byte{}) != 0 {
+		t.Errorf("search for zeroes didn't find 0")
+	}
+}
+
+func benchArrays16(b *testing.B, sort func(a [][16]byte)) {
+	b.StopTimer()
+	a := make([][16]byte, 1e6)
+	for i := 0; i < b.N; i++ {
+		for j := range a {
+			rand.Read(a[j][:])
+		}
+		b.StartTimer()
+		sort(a)
+		b.StopTimer()
+	}
+}
+
+func BenchmarkArrays16(b *testing.B) { benchArrays16(b, Arrays16) }
+
+func BenchmarkArrays16AsBytes(b *testing.B) {
+	benchArrays16(b, func(a [][16]byte) {
+		s := make([][]byte, len(a))
+		for i := range a {
+			s[i] = a[i][:]
+		}
+		Bytes(s)
+	})
+}
@@ -0,0 +1,33 @@
+// Copyright 2015 Randall Farmer. All rights reserved.
+
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+package sortutil
+
+import "math"
+
+// Float64Bucket returns the start of the epsilon-wide bucket x falls in,
+// math.Floor(x/epsilon)*epsilon, for binning values that are close but not
+// equal.  It's a coarse grouping, not a tolerance: values a hair apart can
+// land in different buckets if a boundary falls between them.  Infinities
+// and NaNs are their own buckets.  epsilon must be positive.
+func Float64Bucket(x, epsilon float64) float64 {
+	if !(epsilon > 0) {
+		panic("sortutil: Float64Bucket needs a positive epsilon")
+	}
+	return math.Floor(x/epsilon) * epsilon
+}
+
+// SortFloat64Bucketed sorts a so values in the same Float64Bucket are
+// together, in increasing order of bucket, and in increasing order within
+// each bucket.  Buckets rise with x, so that's the same order Float64s
+// puts a in, NaNs included, and this just checks epsilon and calls Float64s;
+// walk the result with Float64Bucket to find where each bucket starts.
+// epsilon must be positive.
+func SortFloat64Bucketed(a []float64, epsilon float64) {
+	if !(epsilon > 0) {
+		panic("sortutil: SortFloat64Bucketed needs a positive epsilon")
+	}
+	Float64s(a)
+}
@@ -0,0 +1,48 @@
+// Copyright 2015 Randall Farmer. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sortutil_test
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	. "github.com/twotwotwo/sorts/sortutil"
+)
+
+func TestSortFloat64Bucketed(t *testing.T) {
+	const epsilon = 0.25
+	a := make([]float64, testSize)
+	for i := range a {
+		a[i] = rand.NormFloat64()
+		if i < len(float64s) {
+			a[i] = float64s[i]
+		}
+	}
+	SortFloat64Bucketed(a, epsilon)
+	if !Float64sAreSorted(a) {
+		t.Fatalf("didn't sort")
+	}
+	seen := map[float64]bool{}
+	for i := range a {
+		b := Float64Bucket(a[i], epsilon)
+		if math.IsNaN(b) {
+			continue
+		}
+		if seen[b] && Float64Bucket(a[i-1], epsilon) != b {
+			t.Fatalf("bucket %v isn't contiguous", b)
+		}
+		seen[b] = true
+		if a[i] < b || (b+epsilon > b && a[i] >= b+epsilon) { // b+epsilon == b for huge b
+			t.Errorf("%v put in bucket %v", a[i], b)
+		}
+	}
+	defer func() {
+		if recover() == nil {
+			t.Errorf("zero epsilon didn't panic")
+		}
+	}()
+	SortFloat64Bucketed(a, 0)
+}
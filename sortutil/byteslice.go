@@ -0,0 +1,50 @@
+// Copyright 2015 Randall Farmer. All rights reserved.
+
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+package sortutil
+
+import "sort"
+
+// ByteSlice attaches the methods of Uint64Interface to []byte, sorting the
+// individual bytes in increasing order.  (BytesSlice is for [][]byte.)
+type ByteSlice []byte
+
+func (p ByteSlice) Len() int           { return len(p) }
+func (p ByteSlice) Less(i, j int) bool { return p[i] < p[j] }
+func (p ByteSlice) Swap(i, j int)      { p[i], p[j] = p[j], p[i] }
+
+// Key produces a radix sort key for a byte.
+func (p ByteSlice) Key(i int) uint64 { return uint64(p[i]) }
+
+// Sort counting sorts the bytes: one pass counts each value, and another
+// writes them back in order, with no comparisons or swaps.
+func (p ByteSlice) Sort() {
+	var counts [256]int
+	for _, b := range p {
+		counts[b]++
+	}
+	i := 0
+	for b, c := range counts {
+		for end := i + c; i < end; i++ {
+			p[i] = byte(b)
+		}
+	}
+}
+
+// Search returns the result of applying SearchBytesValues to the receiver
+// and x.
+func (p ByteSlice) Search(x byte) int { return SearchBytesValues(p, x) }
+
+// SortBytesValues sorts the bytes in a in increasing order.
+func SortBytesValues(a []byte) { ByteSlice(a).Sort() }
+
+// BytesValuesAreSorted tests whether the bytes in a are sorted in
+// increasing order.
+func BytesValuesAreSorted(a []byte) bool { return sort.IsSorted(ByteSlice(a)) }
+
+// SearchBytesValues searches bytes; read about sort.Search for more.
+func SearchBytesValues(a []byte, x byte) int {
+	return sort.Search(len(a), func(i int) bool { return a[i] >= x })
+}
@@ -0,0 +1,45 @@
+// Copyright 2015 Randall Farmer. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sortutil_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/twotwotwo/sorts"
+	. "github.com/twotwotwo/sorts/sortutil"
+)
+
+func TestSortBytesValues(t *testing.T) {
+	a := make([]byte, testSize)
+	rand.Read(a)
+	a[0], a[1] = 0, 255
+	counts := [256]int{}
+	for _, b := range a {
+		counts[b]++
+	}
+	SortBytesValues(a)
+	if !BytesValuesAreSorted(a) || a[0] != 0 || a[len(a)-1] != 255 {
+		t.Errorf("bytes not sorted")
+	}
+	for _, b := range a {
+		counts[b]--
+	}
+	for b, c := range counts {
+		if c != 0 {
+			t.Fatalf("sorting changed the count of %d by %d", b, -c)
+		}
+	}
+	if i := ByteSlice(a).Search(255); a[i] != 255 || a[i-1] == 255 {
+		t.Errorf("Search(255) found %d", i)
+	}
+
+	// the Key and Less methods radix sort through the sorts package too
+	rand.Read(a)
+	sorts.ByUint64(ByteSlice(a))
+	if !BytesValuesAreSorted(a) {
+		t.Errorf("ByUint64 didn't sort ByteSlice")
+	}
+}
@@ -0,0 +1,67 @@
+// Copyright 2015 Randall Farmer. All rights reserved.
+
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+package sortutil
+
+import (
+	"bytes"
+	"sort"
+
+	"github.com/twotwotwo/sorts"
+)
+
+// CollatedStringSlice sorts Strings by precomputed collation keys, such as
+// a golang.org/x/text/collate.Collator produces for a locale, so the
+// expensive collation work happens once per string and sorting radix sorts
+// the keys.  It implements sorts.BytesInterface; swaps move Strings and
+// Keys together.
+type CollatedStringSlice struct {
+	Strings []string
+	Keys    [][]byte
+}
+
+// NewCollatedStringSlice calls key once for each string in a to make a
+// CollatedStringSlice.  With x/text/collate, pass something like
+//
+//	var buf collate.Buffer
+//	key := func(s string) []byte { return c.KeyFromString(&buf, s) }
+//
+// The keys key returns must stay unchanged until sorting's done; a
+// collate.Buffer's do until it's Reset.
+func NewCollatedStringSlice(a []string, key func(s string) []byte) CollatedStringSlice {
+	keys := make([][]byte, len(a))
+	for i, s := range a {
+		keys[i] = key(s)
+	}
+	return CollatedStringSlice{a, keys}
+}
+
+func (p CollatedStringSlice) Len() int           { return len(p.Strings) }
+func (p CollatedStringSlice) Less(i, j int) bool { return bytes.Compare(p.Keys[i], p.Keys[j]) < 0 }
+func (p CollatedStringSlice) Swap(i, j int) {
+	p.Strings[i], p.Strings[j] = p.Strings[j], p.Strings[i]
+	p.Keys[i], p.Keys[j] = p.Keys[j], p.Keys[i]
+}
+
+// Key returns the collation key of string i.
+func (p CollatedStringSlice) Key(i int) []byte { return p.Keys[i] }
+
+// Sort is a convenience method.
+func (p CollatedStringSlice) Sort() { sorts.ByBytes(p) }
+
+// Search finds the first string whose collation key is >= key; read about
+// sort.Search for more.
+func (p CollatedStringSlice) Search(key []byte) int {
+	return sort.Search(len(p.Keys), func(i int) bool { return bytes.Compare(p.Keys[i], key) >= 0 })
+}
+
+// SortCollated sorts a by the collation keys key returns, as
+// NewCollatedStringSlice describes.  Strings with equal keys end up in no
+// particular order.  It takes a key func rather than a *collate.Collator
+// so sortutil needn't depend on golang.org/x/text; see the example for
+// the one-line adapter.
+func SortCollated(a []string, key func(s string) []byte) {
+	NewCollatedStringSlice(a, key).Sort()
+}
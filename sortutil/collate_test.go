@@ -0,0 +1,47 @@
+// Copyright 2015 Randall Farmer. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sortutil_test
+
+import (
+	"bytes"
+	"sort"
+	"testing"
+
+	. "github.com/twotwotwo/sorts/sortutil"
+)
+
+// foldKey is a stand-in collation key: case-insensitive, then by case.
+func foldKey(s string) []byte {
+	key := append(bytes.ToLower([]byte(s)), 0)
+	return append(key, s...)
+}
+
+func TestSortCollated(t *testing.T) {
+	words := []string{"banana", "Apple", "cherry", "apple", "Banana", "date"}
+	a := make([]string, testSize)
+	for i := range a {
+		a[i] = words[i%len(words)]
+	}
+	calls := 0
+	SortCollated(a, func(s string) []byte {
+		calls++
+		return foldKey(s)
+	})
+	if calls != len(a) {
+		t.Errorf("key called %d times for %d strings", calls, len(a))
+	}
+	if !sort.SliceIsSorted(a, func(i, j int) bool { return string(foldKey(a[i])) < string(foldKey(a[j])) }) {
+		t.Errorf("not in collation order: %v", a[:10])
+	}
+	if a[0] != "Apple" || a[len(a)-1] != "date" {
+		t.Errorf("got %q first and %q last", a[0], a[len(a)-1])
+	}
+
+	p := NewCollatedStringSlice([]string{"b", "A", "a"}, foldKey)
+	p.Sort()
+	if i := p.Search(foldKey("a")); p.Strings[i] != "a" {
+		t.Errorf("search for a found %q", p.Strings[i])
+	}
+}
@@ -0,0 +1,41 @@
+// Copyright 2015 Randall Farmer. All rights reserved.
+
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+package sortutil
+
+import (
+	"math/cmplx"
+	"sort"
+
+	"github.com/twotwotwo/sorts"
+)
+
+// ComplexByMagnitude attaches the methods of Uint64Interface to
+// []complex128, sorting in increasing order by magnitude (cmplx.Abs), then
+// by phase (cmplx.Phase) for equal magnitudes.  Values with a NaN part
+// sort last.
+type ComplexByMagnitude []complex128
+
+func (p ComplexByMagnitude) Len() int { return len(p) }
+func (p ComplexByMagnitude) Less(i, j int) bool {
+	if ki, kj := p.Key(i), p.Key(j); ki != kj {
+		return ki < kj
+	}
+	return Float64Less(cmplx.Phase(p[i]), cmplx.Phase(p[j]))
+}
+func (p ComplexByMagnitude) Swap(i, j int) { p[i], p[j] = p[j], p[i] }
+
+// Key returns the Float64Key of the item's magnitude.
+func (p ComplexByMagnitude) Key(i int) uint64 { return Float64Key(cmplx.Abs(p[i])) }
+
+// Sort is a convenience method.
+func (p ComplexByMagnitude) Sort() { sorts.ByUint64(p) }
+
+// Search returns the index of the first item with magnitude >= mag, or
+// len(p) if there is none; read about sort.Search for more.
+func (p ComplexByMagnitude) Search(mag float64) int {
+	k := Float64Key(mag)
+	return sort.Search(len(p), func(i int) bool { return p.Key(i) >= k })
+}
@@ -0,0 +1,37 @@
+// Copyright 2015 Randall Farmer. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sortutil_test
+
+import (
+	"math"
+	"math/cmplx"
+	"sort"
+	"testing"
+
+	. "github.com/twotwotwo/sorts/sortutil"
+)
+
+func TestComplexByMagnitude(t *testing.T) {
+	// magnitudes collide a lot: every point on each of a few circles
+	vals := []complex128{1, -1, 1i, -1i, 3 + 4i, 4 + 3i, -5, 0, cmplx.NaN(), 2}
+	a := make(ComplexByMagnitude, testSize)
+	for i := range a {
+		a[i] = vals[i%len(vals)]
+	}
+	a.Sort()
+	if !sort.IsSorted(a) {
+		t.Errorf("got %v", a)
+	}
+	if a[0] != 0 || !cmplx.IsNaN(a[len(a)-1]) {
+		t.Errorf("expected 0 first and NaN last, got %v and %v", a[0], a[len(a)-1])
+	}
+	i := a.Search(1)
+	if a[i] != -1i || cmplx.Abs(a[i-1]) >= 1 {
+		t.Errorf("Search(1) found %v at %d", a[i], i)
+	}
+	if j := a.Search(math.Inf(1)); !cmplx.IsNaN(a[j]) {
+		t.Errorf("Search(+Inf) found %v", a[j])
+	}
+}
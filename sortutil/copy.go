@@ -0,0 +1,34 @@
+// Copyright 2015 Randall Farmer. All rights reserved.
+
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+package sortutil
+
+// SortedCopyInts returns a sorted copy of src, leaving src untouched.
+func SortedCopyInts(src []int) []int {
+	a := append([]int(nil), src...)
+	Ints(a)
+	return a
+}
+
+// SortedCopyInt64s returns a sorted copy of src, leaving src untouched.
+func SortedCopyInt64s(src []int64) []int64 {
+	a := append([]int64(nil), src...)
+	Int64s(a)
+	return a
+}
+
+// SortedCopyUint64s returns a sorted copy of src, leaving src untouched.
+func SortedCopyUint64s(src []uint64) []uint64 {
+	a := append([]uint64(nil), src...)
+	Uint64s(a)
+	return a
+}
+
+// SortedCopyStrings returns a sorted copy of src, leaving src untouched.
+func SortedCopyStrings(src []string) []string {
+	a := append([]string(nil), src...)
+	Strings(a)
+	return a
+}
@@ -0,0 +1,42 @@
+// Copyright 2015 Randall Farmer. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sortutil_test
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+
+	. "github.com/twotwotwo/sorts/sortutil"
+)
+
+func TestSortedCopy(t *testing.T) {
+	src := make([]int, testSize)
+	for i := range src {
+		src[i] = rand.Int() - rand.Int()
+	}
+	orig := append([]int(nil), src...)
+	a := SortedCopyInts(src)
+	if !sort.IntsAreSorted(a) || len(a) != len(src) {
+		t.Errorf("SortedCopyInts didn't return a sorted copy")
+	}
+	for i := range src {
+		if src[i] != orig[i] {
+			t.Fatalf("SortedCopyInts changed src")
+		}
+	}
+	if len(SortedCopyInts(nil)) != 0 {
+		t.Errorf("SortedCopyInts(nil) wasn't empty")
+	}
+
+	s := strings[:]
+	sorted := SortedCopyStrings(s)
+	if !sort.StringsAreSorted(sorted) || s[1] != "Hello" {
+		t.Errorf("SortedCopyStrings didn't sort a copy: %v, src %v", sorted, s)
+	}
+	if !Int64sAreSorted(SortedCopyInt64s([]int64{3, -1, 2})) || !Uint64sAreSorted(SortedCopyUint64s([]uint64{3, 1, 2})) {
+		t.Errorf("SortedCopyInt64s or SortedCopyUint64s didn't sort")
+	}
+}
@@ -0,0 +1,28 @@
+// Copyright 2015 Randall Farmer. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sortutil_test
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/twotwotwo/sorts/sortutil"
+)
+
+func ExampleSortCollated() {
+	// With golang.org/x/text/collate, the key func wraps a Collator:
+	//
+	//	c := collate.New(language.Swedish)
+	//	var buf collate.Buffer
+	//	key := func(s string) []byte { return c.KeyFromString(&buf, s) }
+	//
+	// This example uses a case-insensitive key instead, so it runs
+	// without x/text.
+	key := func(s string) []byte { return bytes.ToLower([]byte(s)) }
+	names := []string{"bob", "Alice", "carol", "Bea"}
+	sortutil.SortCollated(names, key)
+	fmt.Println(names)
+	// Output: [Alice Bea bob carol]
+}
@@ -0,0 +1,42 @@
+// Copyright 2015 Randall Farmer. All rights reserved.
+
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+package sortutil
+
+// Float16Key generates a uint64 key from the bits of an IEEE 754
+// half-precision float, so keys sort in numeric order, -0 before +0, and
+// NaNs last.  Go has no float16 type, so you pass the raw bits, e.g. as
+// read from a tensor.  Use with Float16Less.
+func Float16Key(bits uint16) uint64 {
+	if bits&0x7fff > 0x7c00 { // exponent all ones, nonzero mantissa
+		return ^uint64(0)
+	}
+	return halfKey(bits)
+}
+
+// Float16Less compares the bits of half-precision floats, treating NaN as
+// greater than all numbers.
+func Float16Less(f, g uint16) bool { return Float16Key(f) < Float16Key(g) }
+
+// BFloat16Key is Float16Key for bfloat16 (the top half of a float32).  Use
+// with BFloat16Less.
+func BFloat16Key(bits uint16) uint64 {
+	if bits&0x7fff > 0x7f80 {
+		return ^uint64(0)
+	}
+	return halfKey(bits)
+}
+
+// BFloat16Less compares the bits of bfloat16s, treating NaN as greater
+// than all numbers.
+func BFloat16Less(f, g uint16) bool { return BFloat16Key(f) < BFloat16Key(g) }
+
+// halfKey flips the sign bit of positive numbers and every bit of negative
+// ones, like Float32Key.
+func halfKey(bits uint16) uint64 {
+	b := uint64(bits) << 48
+	b ^= ^(b>>63 - 1) | (1 << 63)
+	return b
+}
@@ -0,0 +1,80 @@
+// Copyright 2015 Randall Farmer. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sortutil_test
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	. "github.com/twotwotwo/sorts/sortutil"
+)
+
+// checkHalfOrder checks that key puts bits in increasing order, with the
+// last nans entries all NaN.
+func checkHalfOrder(t *testing.T, name string, key func(uint16) uint64, bits []uint16, nans int) {
+	for i := 1; i < len(bits); i++ {
+		k1, k2 := key(bits[i-1]), key(bits[i])
+		if i >= len(bits)-nans+1 {
+			if k1 != k2 {
+				t.Errorf("%s: NaNs %#04x and %#04x have different keys", name, bits[i-1], bits[i])
+			}
+		} else if k1 >= k2 {
+			t.Errorf("%s: %#04x doesn't sort before %#04x", name, bits[i-1], bits[i])
+		}
+	}
+}
+
+func TestFloat16Key(t *testing.T) {
+	checkHalfOrder(t, "Float16Key", Float16Key, []uint16{
+		0xfc00, // -Inf
+		0xfbff, // -max
+		0xc000, // -2
+		0xbc00, // -1
+		0x8001, // -smallest subnormal
+		0x8000, // -0
+		0x0000, // +0
+		0x0001, // smallest subnormal
+		0x0400, // smallest normal
+		0x3c00, // 1
+		0x4000, // 2
+		0x7bff, // max
+		0x7c00, // +Inf
+		0x7c01, // NaNs
+		0x7e00,
+		0xfe00,
+		0xffff,
+	}, 4)
+	if !Float16Less(0xbc00, 0x3c00) || Float16Less(0x7e00, 0x7c00) {
+		t.Errorf("Float16Less disagrees with Float16Key")
+	}
+}
+
+func TestBFloat16Key(t *testing.T) {
+	checkHalfOrder(t, "BFloat16Key", BFloat16Key, []uint16{
+		0xff80, // -Inf
+		0xbf80, // -1
+		0x8000, // -0
+		0x0000, // +0
+		0x3f80, // 1
+		0x7f7f, // max
+		0x7f80, // +Inf
+		0x7f81, // NaNs
+		0x7fc0,
+		0xffc0,
+	}, 3)
+
+	// bfloat16s sort like the float32s they're the top half of
+	for i := 0; i < 10000; i++ {
+		a, b := uint16(rand.Intn(1<<16)), uint16(rand.Intn(1<<16))
+		f, g := math.Float32frombits(uint32(a)<<16), math.Float32frombits(uint32(b)<<16)
+		if f != f || g != g || (f == 0 && g == 0) {
+			continue
+		}
+		if BFloat16Less(a, b) != (f < g) {
+			t.Fatalf("BFloat16Less(%#04x, %#04x) disagrees with float32 %v < %v", a, b, f, g)
+		}
+	}
+}
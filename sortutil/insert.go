@@ -0,0 +1,56 @@
+// Copyright 2015 Randall Farmer. All rights reserved.
+
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+package sortutil
+
+import (
+	"cmp"
+	"slices"
+	"sort"
+)
+
+// The InsertSorted* funcs add items to a slice that's already sorted in
+// increasing order, keeping it sorted.  They sort items in place, then
+// merge them in from the end, finding where each goes by binary search
+// and shifting the sorted items after it with copy, so adding m items to n
+// costs about m log n comparisons plus moving each sorted item at most
+// once.  That's much cheaper than re-sorting everything when m is small.
+// Like append, they return the extended slice, which shares sorted's
+// backing array if it has room.  New items go after equal sorted ones.
+
+// InsertSortedInts merges items into sorted, as described above.
+func InsertSortedInts(sorted, items []int) []int {
+	Ints(items)
+	return insertSorted(sorted, items)
+}
+
+// InsertSortedUint64s merges items into sorted, as described above.
+func InsertSortedUint64s(sorted, items []uint64) []uint64 {
+	Uint64s(items)
+	return insertSorted(sorted, items)
+}
+
+// InsertSortedStrings merges items into sorted, as described above.
+func InsertSortedStrings(sorted, items []string) []string {
+	Strings(items)
+	return insertSorted(sorted, items)
+}
+
+// insertSorted merges sorted items into sorted.
+func insertSorted[T cmp.Ordered](sorted, items []T) []T {
+	n, m := len(sorted), len(items)
+	out := slices.Grow(sorted, m)[:n+m]
+	k, hi := n+m, n // out[k:] is done; out[:hi] is what's left of sorted
+	for j := m - 1; j >= 0; j-- {
+		x := items[j]
+		p := sort.Search(hi, func(i int) bool { return out[i] > x })
+		k -= hi - p
+		copy(out[k:], out[p:hi])
+		k--
+		out[k] = x
+		hi = p
+	}
+	return out
+}
@@ -0,0 +1,76 @@
+// Copyright 2015 Randall Farmer. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sortutil_test
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+
+	. "github.com/twotwotwo/sorts/sortutil"
+)
+
+func TestInsertSorted(t *testing.T) {
+	for _, m := range []int{0, 1, 10, testSize} {
+		sorted := make([]int, testSize)
+		for i := range sorted {
+			sorted[i] = ints[i%len(ints)]
+		}
+		Ints(sorted)
+		items := make([]int, m)
+		for i := range items {
+			items[i] = rand.Intn(2000) - 1000
+		}
+		want := append(append([]int(nil), sorted...), items...)
+		sort.Ints(want)
+
+		got := InsertSortedInts(sorted, items)
+		if len(got) != len(want) {
+			t.Fatalf("m=%d: got %d ints, want %d", m, len(got), len(want))
+		}
+		for i := range got {
+			if got[i] != want[i] {
+				t.Fatalf("m=%d: got[%d] = %d, want %d", m, i, got[i], want[i])
+			}
+		}
+	}
+
+	s := InsertSortedStrings([]string{"a", "c", "e"}, []string{"f", "b", "a", "d"})
+	if !sort.StringsAreSorted(s) || len(s) != 7 {
+		t.Errorf("strings: got %v", s)
+	}
+	u := InsertSortedUint64s(nil, []uint64{3, 1, 2})
+	if !Uint64sAreSorted(u) || len(u) != 3 {
+		t.Errorf("uint64s: got %v", u)
+	}
+}
+
+func BenchmarkInsertSortedInts(b *testing.B) {
+	sorted := make([]int, 1e6)
+	for i := range sorted {
+		sorted[i] = i * 2
+	}
+	items := make([]int, 1000)
+	for i := 0; i < b.N; i++ {
+		for j := range items {
+			items[j] = rand.Intn(2e6)
+		}
+		InsertSortedInts(sorted[:len(sorted):len(sorted)], items)
+	}
+}
+
+func BenchmarkInsertByResorting(b *testing.B) {
+	sorted := make([]int, 1e6)
+	for i := range sorted {
+		sorted[i] = i * 2
+	}
+	items := make([]int, 1000)
+	for i := 0; i < b.N; i++ {
+		for j := range items {
+			items[j] = rand.Intn(2e6)
+		}
+		Ints(append(sorted[:len(sorted):len(sorted)], items...))
+	}
+}
@@ -0,0 +1,59 @@
+// Copyright 2015 Randall Farmer. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sortutil_test
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	. "github.com/twotwotwo/sorts/sortutil"
+)
+
+func TestInt16s(t *testing.T) {
+	// both sides of the counting-sort cutoff
+	for _, n := range []int{testSize, 1 << 13} {
+		a := make([]int16, n)
+		u := make([]uint16, n)
+		for i := range a {
+			a[i] = int16(rand.Intn(1 << 16))
+			u[i] = uint16(rand.Intn(1 << 16))
+		}
+		a[0], a[1], u[0] = math.MinInt16, math.MaxInt16, math.MaxUint16
+		Int16s(a)
+		Uint16s(u)
+		if !Int16sAreSorted(a) || a[0] != math.MinInt16 || a[n-1] != math.MaxInt16 {
+			t.Errorf("%d int16s not sorted", n)
+		}
+		if !Uint16sAreSorted(u) || u[n-1] != math.MaxUint16 {
+			t.Errorf("%d uint16s not sorted", n)
+		}
+		if Int16Slice(a).Search(math.MinInt16) != 0 || SearchUint16s(u, 0) != 0 {
+			t.Errorf("search failed")
+		}
+	}
+}
+
+func benchInt16s(b *testing.B, n int, sort func([]int16)) {
+	b.StopTimer()
+	a := make([]int16, n)
+	for i := 0; i < b.N; i++ {
+		for i := range a {
+			a[i] = int16(rand.Intn(1 << 16))
+		}
+		b.StartTimer()
+		sort(a)
+		b.StopTimer()
+	}
+}
+
+func radixInt16s(a []int16) { Int16Slice(a).Sort() }
+
+func BenchmarkInt16s2e3(b *testing.B)      { benchInt16s(b, 2e3, Int16s) }
+func BenchmarkInt16s2e3Radix(b *testing.B) { benchInt16s(b, 2e3, radixInt16s) }
+func BenchmarkInt16s1e4(b *testing.B)      { benchInt16s(b, 1e4, Int16s) }
+func BenchmarkInt16s1e4Radix(b *testing.B) { benchInt16s(b, 1e4, radixInt16s) }
+func BenchmarkInt16s1e5(b *testing.B)      { benchInt16s(b, 1e5, Int16s) }
+func BenchmarkInt16s1e5Radix(b *testing.B) { benchInt16s(b, 1e5, radixInt16s) }
@@ -0,0 +1,64 @@
+// Copyright 2015 Randall Farmer. All rights reserved.
+
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+package sortutil
+
+import "sort"
+
+// Int8Slice attaches the methods of Int64Interface to []int8, sorting in
+// increasing order.  Its Sort counting sorts, like ByteSlice's.
+type Int8Slice []int8
+
+func (p Int8Slice) Len() int           { return len(p) }
+func (p Int8Slice) Less(i, j int) bool { return p[i] < p[j] }
+func (p Int8Slice) Swap(i, j int)      { p[i], p[j] = p[j], p[i] }
+
+// Key produces a radix sort key for an integer item.
+func (p Int8Slice) Key(i int) int64 { return int64(p[i]) }
+
+// Sort counting sorts the int8s: one pass counts each value, and another
+// writes them back in order, with no comparisons or swaps.
+func (p Int8Slice) Sort() {
+	var counts [256]int
+	for _, v := range p {
+		counts[uint8(v)^1<<7]++
+	}
+	i := 0
+	for k, c := range counts {
+		v := int8(uint8(k) ^ 1<<7)
+		for end := i + c; i < end; i++ {
+			p[i] = v
+		}
+	}
+}
+
+// Search returns the result of applying SearchInt8s to the receiver and x.
+func (p Int8Slice) Search(x int8) int { return SearchInt8s(p, x) }
+
+// Int8s sorts a slice of int8s in increasing order.
+func Int8s(a []int8) { Int8Slice(a).Sort() }
+
+// Int8sAreSorted tests whether a slice of int8s is sorted in increasing
+// order.
+func Int8sAreSorted(a []int8) bool { return sort.IsSorted(Int8Slice(a)) }
+
+// SearchInt8s searches int8s; read about sort.Search for more.
+func SearchInt8s(a []int8, x int8) int {
+	return sort.Search(len(a), func(i int) bool { return a[i] >= x })
+}
+
+// Uint8Slice is ByteSlice under the name that goes with Int8Slice.
+type Uint8Slice = ByteSlice
+
+// Uint8s sorts a slice of uint8s in increasing order; it's
+// SortBytesValues.
+func Uint8s(a []uint8) { ByteSlice(a).Sort() }
+
+// Uint8sAreSorted tests whether a slice of uint8s is sorted in increasing
+// order.
+func Uint8sAreSorted(a []uint8) bool { return BytesValuesAreSorted(a) }
+
+// SearchUint8s searches uint8s; read about sort.Search for more.
+func SearchUint8s(a []uint8, x uint8) int { return SearchBytesValues(a, x) }
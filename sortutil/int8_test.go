@@ -0,0 +1,55 @@
+// Copyright 2015 Randall Farmer. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sortutil_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/twotwotwo/sorts"
+	. "github.com/twotwotwo/sorts/sortutil"
+)
+
+func TestInt8s(t *testing.T) {
+	a := make([]int8, testSize)
+	for i := range a {
+		a[i] = int8(rand.Intn(256) - 128)
+	}
+	a[0], a[1] = 127, -128
+	counts := map[int8]int{}
+	for _, v := range a {
+		counts[v]++
+	}
+	Int8s(a)
+	if !Int8sAreSorted(a) || a[0] != -128 || a[len(a)-1] != 127 {
+		t.Errorf("int8s not sorted")
+	}
+	for _, v := range a {
+		counts[v]--
+	}
+	for v, c := range counts {
+		if c != 0 {
+			t.Fatalf("sorting changed the count of %d by %d", v, -c)
+		}
+	}
+	if i := Int8Slice(a).Search(0); a[i] < 0 || i > 0 && a[i-1] >= 0 {
+		t.Errorf("Search(0) found %d", i)
+	}
+
+	// and the radix sort agrees
+	for i := range a {
+		a[i] = int8(rand.Intn(256) - 128)
+	}
+	sorts.ByInt64(Int8Slice(a))
+	if !Int8sAreSorted(a) {
+		t.Errorf("ByInt64 didn't sort int8s")
+	}
+
+	u := []uint8{3, 255, 0, 7}
+	Uint8s(u)
+	if !Uint8sAreSorted(u) || SearchUint8s(u, 7) != 2 {
+		t.Errorf("uint8s: got %v", u)
+	}
+}
@@ -0,0 +1,50 @@
+// Copyright 2015 Randall Farmer. All rights reserved.
+
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+package sortutil
+
+import (
+	"bytes"
+	"net"
+	"sort"
+
+	"github.com/twotwotwo/sorts"
+)
+
+// IPSlice attaches the methods of BytesInterface to []net.IP, sorting in
+// increasing numeric order of the 16-byte form, so 4-byte IPv4 addresses
+// sort the same as their IPv4-in-IPv6 forms (after most other IPv6
+// addresses starting with 0, before the rest).  Invalid IPs sort first.
+//
+// Key has to allocate to convert 4-byte addresses, so sorting is faster if
+// all addresses are already in 16-byte form (see net.IP.To16).
+type IPSlice []net.IP
+
+func (p IPSlice) Len() int           { return len(p) }
+func (p IPSlice) Less(i, j int) bool { return bytes.Compare(p[i].To16(), p[j].To16()) < 0 }
+func (p IPSlice) Swap(i, j int)      { p[i], p[j] = p[j], p[i] }
+
+// Key returns the 16-byte form of an address.
+func (p IPSlice) Key(i int) []byte { return p[i].To16() }
+
+// Sort is a convenience method.
+func (p IPSlice) Sort() { sorts.ByBytes(p) }
+
+// IPs sorts a slice of IP addresses in increasing order.
+func IPs(a []net.IP) { IPSlice(a).Sort() }
+
+// IPsAreSorted tests whether a slice of IP addresses is sorted in
+// increasing order.
+func IPsAreSorted(a []net.IP) bool { return sort.IsSorted(IPSlice(a)) }
+
+// SearchIPs finds the first address >= x, in 16-byte form; read about
+// sort.Search for more.
+func SearchIPs(a []net.IP, x net.IP) int {
+	x = x.To16()
+	return sort.Search(len(a), func(i int) bool { return bytes.Compare(a[i].To16(), x) >= 0 })
+}
+
+// Search returns the result of applying SearchIPs to the receiver and x.
+func (p IPSlice) Search(x net.IP) int { return SearchIPs(p, x) }
@@ -0,0 +1,52 @@
+// Copyright 2015 Randall Farmer. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sortutil_test
+
+import (
+	"net"
+	"sort"
+	"testing"
+
+	. "github.com/twotwotwo/sorts/sortutil"
+)
+
+func TestSortIPSlice(t *testing.T) {
+	data := []net.IP{
+		net.ParseIP("10.0.0.1").To4(),
+		net.ParseIP("10.0.0.1"), // same, as IPv4-in-IPv6
+		net.ParseIP("9.255.255.255").To4(),
+		net.ParseIP("192.168.1.1"),
+		net.ParseIP("::1"),
+		net.ParseIP("2001:db8::1"),
+		net.ParseIP("fe80::1"),
+		net.IPv4(255, 255, 255, 255).To4(),
+	}
+	a := make(IPSlice, testSize)
+	for i := range a {
+		a[i] = data[i%len(data)]
+	}
+	a.Sort()
+	if !sort.IsSorted(a) {
+		t.Errorf("IPs didn't sort: %v", a)
+	}
+	if !a[0].Equal(net.ParseIP("::1")) || !a[len(a)-1].Equal(net.ParseIP("fe80::1")) {
+		t.Errorf("got unexpected order: %v", a)
+	}
+	i := a.Search(net.ParseIP("10.0.0.1").To4())
+	if !a[i].Equal(net.ParseIP("10.0.0.1")) || a[i-1].Equal(a[i]) {
+		t.Errorf("search for 10.0.0.1 found %d", i)
+	}
+	if a.Search(net.ParseIP("::")) != 0 || a.Search(net.ParseIP("ffff::")) != len(a) {
+		t.Errorf("search failed")
+	}
+}
+
+func TestIPs(t *testing.T) {
+	data := []net.IP{net.ParseIP("10.0.0.2"), net.ParseIP("10.0.0.1").To4(), net.ParseIP("::")}
+	IPs(data)
+	if !IPsAreSorted(data) {
+		t.Errorf("got %v", data)
+	}
+}
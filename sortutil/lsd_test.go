@@ -0,0 +1,48 @@
+// Copyright 2015 Randall Farmer. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sortutil_test
+
+import (
+	"math/rand"
+	"testing"
+
+	. "github.com/twotwotwo/sorts/sortutil"
+)
+
+func TestLSDUint64s(t *testing.T) {
+	for _, size := range []int{0, 1, 2, testSize, 100000} {
+		data := make([]uint64, size)
+		for i := range data {
+			switch i % 3 {
+			case 0:
+				data[i] = uint64(rand.Int63()) << 1
+			case 1:
+				data[i] = uint64(rand.Intn(1000)) // only the low bytes vary
+			default:
+				data[i] = ^uint64(0)
+			}
+		}
+		LSDUint64s(data)
+		if !Uint64sAreSorted(data) {
+			t.Errorf("size %d: not sorted", size)
+		}
+	}
+}
+
+func benchmarkUint64s(b *testing.B, sort func([]uint64)) {
+	b.StopTimer()
+	data := make([]uint64, 1e7)
+	for i := 0; i < b.N; i++ {
+		for i := range data {
+			data[i] = uint64(rand.Int63())<<1 ^ uint64(rand.Int63())
+		}
+		b.StartTimer()
+		sort(data)
+		b.StopTimer()
+	}
+}
+
+func BenchmarkUint64s1e7(b *testing.B)    { benchmarkUint64s(b, Uint64s) }
+func BenchmarkLSDUint64s1e7(b *testing.B) { benchmarkUint64s(b, LSDUint64s) }
@@ -0,0 +1,101 @@
+// Copyright 2015 Randall Farmer. All rights reserved.
+
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+package sortutil
+
+import (
+	"cmp"
+
+	"github.com/twotwotwo/sorts"
+)
+
+// SortedKeys returns m's keys in increasing order, radix sorting them if
+// they're of a built-in integer, float, or string type (see sorts.Slice).
+func SortedKeys[K cmp.Ordered, V any](m map[K]V) []K {
+	keys := make([]K, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sorts.Slice(keys)
+	return keys
+}
+
+// SortedKeysByValue returns m's keys in increasing order by their values,
+// breaking ties by key so the result doesn't depend on map iteration
+// order.  Values of type int, int64, uint64, float64, or string are radix
+// sorted; others are quicksorted.  float64 NaNs sort last, as with
+// Float64Less.
+func SortedKeysByValue[K, V cmp.Ordered](m map[K]V) []K {
+	bv := byValue[K, V]{make([]K, 0, len(m)), make([]V, 0, len(m))}
+	for k, v := range m {
+		bv.keys = append(bv.keys, k)
+		bv.vals = append(bv.vals, v)
+	}
+	switch vals := any(bv.vals).(type) {
+	case []int:
+		sorts.ByInt64(intValues[K]{byValue[K, int]{bv.keys, vals}})
+	case []int64:
+		sorts.ByInt64(int64Values[K]{byValue[K, int64]{bv.keys, vals}})
+	case []uint64:
+		sorts.ByUint64(uint64Values[K]{byValue[K, uint64]{bv.keys, vals}})
+	case []float64:
+		sorts.ByUint64(float64Values[K]{byValue[K, float64]{bv.keys, vals}})
+	case []string:
+		sorts.ByString(stringValues[K]{byValue[K, string]{bv.keys, vals}})
+	default:
+		sorts.Quicksort(bv)
+	}
+	return bv.keys
+}
+
+// byValue holds a map's keys and values in matching order, ordered by
+// value and then key.
+type byValue[K, V cmp.Ordered] struct {
+	keys []K
+	vals []V
+}
+
+func (p byValue[K, V]) Len() int { return len(p.keys) }
+func (p byValue[K, V]) Less(i, j int) bool {
+	if c := cmp.Compare(p.vals[i], p.vals[j]); c != 0 {
+		return c < 0
+	}
+	return cmp.Less(p.keys[i], p.keys[j])
+}
+func (p byValue[K, V]) Swap(i, j int) {
+	p.keys[i], p.keys[j] = p.keys[j], p.keys[i]
+	p.vals[i], p.vals[j] = p.vals[j], p.vals[i]
+}
+
+// The *Values types add a Key method for each radix-sortable value type.
+
+type intValues[K cmp.Ordered] struct{ byValue[K, int] }
+
+func (p intValues[K]) Key(i int) int64 { return int64(p.vals[i]) }
+
+type int64Values[K cmp.Ordered] struct{ byValue[K, int64] }
+
+func (p int64Values[K]) Key(i int) int64 { return p.vals[i] }
+
+type uint64Values[K cmp.Ordered] struct{ byValue[K, uint64] }
+
+func (p uint64Values[K]) Key(i int) uint64 { return p.vals[i] }
+
+type float64Values[K cmp.Ordered] struct{ byValue[K, float64] }
+
+// Less orders values the way Key does, which cmp.Compare doesn't for NaNs
+// and negative zero.
+func (p float64Values[K]) Less(i, j int) bool {
+	if ki, kj := Float64Key(p.vals[i]), Float64Key(p.vals[j]); ki != kj {
+		return ki < kj
+	}
+	return cmp.Less(p.keys[i], p.keys[j])
+}
+
+func (p float64Values[K]) Key(i int) uint64 { return Float64Key(p.vals[i]) }
+
+type stringValues[K cmp.Ordered] struct{ byValue[K, string] }
+
+func (p stringValues[K]) Key(i int) string { return p.vals[i] }
@@ -0,0 +1,44 @@
+// Copyright 2015 Randall Farmer. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sortutil_test
+
+import (
+	"math"
+	"reflect"
+	"strconv"
+	"testing"
+
+	. "github.com/twotwotwo/sorts/sortutil"
+)
+
+func TestSortedKeys(t *testing.T) {
+	m := map[string]int{}
+	for i := 0; i < testSize; i++ {
+		m[strconv.Itoa(i)] = ints[i%len(ints)]
+	}
+	keys := SortedKeys(m)
+	if len(keys) != len(m) || !StringsAreSorted(keys) {
+		t.Errorf("SortedKeys didn't sort")
+	}
+	byValue := SortedKeysByValue(m)
+	if len(byValue) != len(m) {
+		t.Fatalf("SortedKeysByValue returned %d keys, want %d", len(byValue), len(m))
+	}
+	for i := 1; i < len(byValue); i++ {
+		a, b := byValue[i-1], byValue[i]
+		if m[a] > m[b] || m[a] == m[b] && a > b {
+			t.Fatalf("%q (%d) came before %q (%d)", a, m[a], b, m[b])
+		}
+	}
+
+	f := map[int]float64{1: math.NaN(), 2: 0.5, 3: math.Inf(-1), 4: 0.5}
+	if got := SortedKeysByValue(f); !reflect.DeepEqual(got, []int{3, 2, 4, 1}) {
+		t.Errorf("float values sorted keys to %v", got)
+	}
+	u := map[string]uint8{"a": 3, "b": 1, "c": 2}
+	if got := SortedKeysByValue(u); !reflect.DeepEqual(got, []string{"b", "c", "a"}) {
+		t.Errorf("uint8 values sorted keys to %v", got)
+	}
+}
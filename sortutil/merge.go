@@ -0,0 +1,96 @@
+// Copyright 2015 Randall Farmer. All rights reserved.
+
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+package sortutil
+
+import "cmp"
+
+// merger is a min-heap of source numbers for a k-way merge, ordered by
+// less, which compares the current heads of two sources.
+type merger struct {
+	heads []int
+	less  func(a, b int) bool
+}
+
+// newMerger makes a heap of the sources 0..n-1 for which nonEmpty is true.
+func newMerger(n int, nonEmpty func(s int) bool, less func(a, b int) bool) *merger {
+	m := &merger{heads: make([]int, 0, n), less: less}
+	for s := 0; s < n; s++ {
+		if nonEmpty(s) {
+			m.heads = append(m.heads, s)
+		}
+	}
+	for i := len(m.heads)/2 - 1; i >= 0; i-- {
+		m.down(i)
+	}
+	return m
+}
+
+// advance restores heap order after the head of source m.heads[0] was
+// consumed, dropping the source if it's now exhausted.
+func (m *merger) advance(exhausted bool) {
+	if exhausted {
+		last := len(m.heads) - 1
+		m.heads[0] = m.heads[last]
+		m.heads = m.heads[:last]
+	}
+	m.down(0)
+}
+
+func (m *merger) down(i int) {
+	h := m.heads
+	for {
+		c := 2*i + 1
+		if c >= len(h) {
+			return
+		}
+		if c+1 < len(h) && m.less(h[c+1], h[c]) {
+			c++
+		}
+		if !m.less(h[c], h[i]) {
+			return
+		}
+		h[i], h[c] = h[c], h[i]
+		i = c
+	}
+}
+
+// MergeInts merges srcs, each of which must already be sorted in
+// increasing order, appending the result to dst and returning the extended
+// slice, like append.  Equal ints come out in the order of the srcs they
+// came from.  It uses a heap of the srcs' heads, so it's meant for up to a
+// few dozen srcs; past that, sorting the concatenation may be as fast.
+func MergeInts(dst []int, srcs ...[]int) []int { return mergeSorted(dst, srcs) }
+
+// MergeUint64s merges sorted slices of uint64s as MergeInts does ints.
+func MergeUint64s(dst []uint64, srcs ...[]uint64) []uint64 { return mergeSorted(dst, srcs) }
+
+// MergeStrings merges sorted slices of strings as MergeInts does ints.
+func MergeStrings(dst []string, srcs ...[]string) []string { return mergeSorted(dst, srcs) }
+
+// mergeSorted does the work of the Merge* funcs.
+func mergeSorted[T cmp.Ordered](dst []T, srcs [][]T) []T {
+	n := len(dst)
+	for _, s := range srcs {
+		n += len(s)
+	}
+	if n > cap(dst) {
+		dst = append(make([]T, 0, n), dst...)
+	}
+	pos := make([]int, len(srcs))
+	m := newMerger(len(srcs),
+		func(s int) bool { return len(srcs[s]) > 0 },
+		func(a, b int) bool {
+			x, y := srcs[a][pos[a]], srcs[b][pos[b]]
+			return x < y || x == y && a < b
+		})
+	for len(m.heads) > 0 {
+		s := m.heads[0]
+		dst = append(dst, srcs[s][pos[s]])
+		pos[s]++
+		m.advance(pos[s] == len(srcs[s]))
+	}
+	return dst
+}
@@ -0,0 +1,46 @@
+// Copyright 2015 Randall Farmer. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sortutil_test
+
+import (
+	"math/rand"
+	"reflect"
+	"sort"
+	"testing"
+
+	. "github.com/twotwotwo/sorts/sortutil"
+)
+
+func TestMergeInts(t *testing.T) {
+	for k := 0; k < 20; k++ {
+		srcs := make([][]int, k)
+		all := []int{}
+		for i := range srcs {
+			srcs[i] = make([]int, rand.Intn(100))
+			for j := range srcs[i] {
+				srcs[i][j] = rand.Intn(50)
+			}
+			Ints(srcs[i])
+			all = append(all, srcs[i]...)
+		}
+		Ints(all)
+		got := MergeInts([]int{-1}, srcs...)
+		if !reflect.DeepEqual(got, append([]int{-1}, all...)) {
+			t.Fatalf("merging %d slices got %v, want %v", k, got, all)
+		}
+	}
+}
+
+func TestMergeStringsAndUint64s(t *testing.T) {
+	srcs := [][]string{{"a", "b", "b"}, {}, {"a", "c"}, {"b"}}
+	got := MergeStrings(nil, srcs...)
+	if !sort.StringsAreSorted(got) || len(got) != 6 {
+		t.Errorf("MergeStrings got %q", got)
+	}
+	us := MergeUint64s(nil, []uint64{1, 1<<63 + 1}, []uint64{0, 1, 1 << 63})
+	if !reflect.DeepEqual(us, []uint64{0, 1, 1, 1 << 63, 1<<63 + 1}) {
+		t.Errorf("MergeUint64s got %v", us)
+	}
+}
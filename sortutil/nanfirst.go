@@ -0,0 +1,99 @@
+// Copyright 2015 Randall Farmer. All rights reserved.
+
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+package sortutil
+
+import (
+	"sort"
+
+	"github.com/twotwotwo/sorts"
+)
+
+// Float64KeyNaNFirst is Float64Key, except that every NaN, whatever its
+// sign, gets key 0, below -Inf's, so NaNs sort first, as some SQL
+// databases order them.  Use with Float64LessNaNFirst.
+func Float64KeyNaNFirst(f float64) uint64 {
+	if f != f {
+		return 0
+	}
+	return Float64Key(f)
+}
+
+// Float64LessNaNFirst compares float64s, treating NaN as less than all
+// numbers.
+func Float64LessNaNFirst(f, g float64) bool {
+	return Float64KeyNaNFirst(f) < Float64KeyNaNFirst(g)
+}
+
+// Float64NaNFirstSlice attaches the methods of Uint64Interface to
+// []float64, sorting in increasing order, NaNs first.
+type Float64NaNFirstSlice []float64
+
+func (p Float64NaNFirstSlice) Len() int           { return len(p) }
+func (p Float64NaNFirstSlice) Less(i, j int) bool { return Float64LessNaNFirst(p[i], p[j]) }
+func (p Float64NaNFirstSlice) Swap(i, j int)      { p[i], p[j] = p[j], p[i] }
+
+// Key produces a radix sort key for a floating-point value.
+func (p Float64NaNFirstSlice) Key(i int) uint64 { return Float64KeyNaNFirst(p[i]) }
+
+// Sort is a convenience method.
+func (p Float64NaNFirstSlice) Sort() { sorts.ByUint64(p) }
+
+// Search returns the result of applying SearchFloat64sNaNFirst to the
+// receiver and x.
+func (p Float64NaNFirstSlice) Search(x float64) int { return SearchFloat64sNaNFirst(p, x) }
+
+// Float64sNaNFirst sorts a slice of float64s in increasing order, NaNs
+// first.
+func Float64sNaNFirst(a []float64) { Float64NaNFirstSlice(a).Sort() }
+
+// Float64sAreSortedNaNFirst tests whether a slice of float64s is sorted in
+// increasing order, NaNs first.
+func Float64sAreSortedNaNFirst(a []float64) bool { return sort.IsSorted(Float64NaNFirstSlice(a)) }
+
+// SearchFloat64sNaNFirst searches float64s sorted NaNs first; read about
+// sort.Search for more.
+func SearchFloat64sNaNFirst(a []float64, x float64) int {
+	k := Float64KeyNaNFirst(x)
+	return sort.Search(len(a), func(i int) bool { return Float64KeyNaNFirst(a[i]) >= k })
+}
+
+// Float64KeyDescNaNFirst generates a uint64 key that sorts float64s in
+// decreasing order with NaNs, whatever their sign, first: Float64Key's
+// bits complemented, and 0 for NaN.  No number's complemented key is 0,
+// since the top Float64Keys all belong to NaNs.
+func Float64KeyDescNaNFirst(f float64) uint64 {
+	if f != f {
+		return 0
+	}
+	return ^Float64Key(f)
+}
+
+// Float64DescNaNFirstSlice attaches the methods of Uint64Interface to
+// []float64, sorting in decreasing order, NaNs first, as for scores where
+// higher is better and missing ones should stand out.  +0 sorts before -0.
+type Float64DescNaNFirstSlice []float64
+
+func (p Float64DescNaNFirstSlice) Len() int { return len(p) }
+func (p Float64DescNaNFirstSlice) Less(i, j int) bool {
+	return Float64KeyDescNaNFirst(p[i]) < Float64KeyDescNaNFirst(p[j])
+}
+func (p Float64DescNaNFirstSlice) Swap(i, j int) { p[i], p[j] = p[j], p[i] }
+
+// Key produces a radix sort key for a floating-point value.
+func (p Float64DescNaNFirstSlice) Key(i int) uint64 { return Float64KeyDescNaNFirst(p[i]) }
+
+// Sort is a convenience method.
+func (p Float64DescNaNFirstSlice) Sort() { sorts.ByUint64(p) }
+
+// SortFloat64DescNaNFirst sorts a slice of float64s in decreasing order,
+// NaNs first.
+func SortFloat64DescNaNFirst(a []float64) { Float64DescNaNFirstSlice(a).Sort() }
+
+// Float64sAreSortedDescNaNFirst tests whether a slice of float64s is
+// sorted in decreasing order, NaNs first.
+func Float64sAreSortedDescNaNFirst(a []float64) bool {
+	return sort.IsSorted(Float64DescNaNFirstSlice(a))
+}
@@ -0,0 +1,93 @@
+// Copyright 2015 Randall Farmer. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sortutil_test
+
+import (
+	"math"
+	"testing"
+
+	. "github.com/twotwotwo/sorts/sortutil"
+)
+
+func TestFloat64sNaNFirst(t *testing.T) {
+	negNaN := math.Copysign(math.NaN(), -1)
+	a := make([]float64, testSize)
+	for i := range a {
+		switch i % 10 {
+		case 0:
+			a[i] = math.NaN()
+		case 1:
+			a[i] = negNaN
+		case 2:
+			a[i] = math.Inf(-1)
+		default:
+			a[i] = float64s[i%len(float64s)]
+		}
+	}
+	nans := 0
+	for _, f := range a {
+		if math.IsNaN(f) {
+			nans++
+		}
+	}
+	Float64sNaNFirst(a)
+	if !Float64sAreSortedNaNFirst(a) {
+		t.Fatalf("not sorted NaNs first")
+	}
+	for i := range a {
+		if math.IsNaN(a[i]) != (i < nans) {
+			t.Fatalf("a[%d] is %v; want the first %d to be NaN", i, a[i], nans)
+		}
+	}
+	if i := SearchFloat64sNaNFirst(a, math.NaN()); i != 0 {
+		t.Errorf("search for NaN found %d", i)
+	}
+	if i := Float64NaNFirstSlice(a).Search(math.Inf(-1)); i != nans || !math.IsInf(a[i], -1) {
+		t.Errorf("search for -Inf found %d", i)
+	}
+	if !Float64LessNaNFirst(negNaN, math.Inf(-1)) || Float64LessNaNFirst(math.NaN(), negNaN) {
+		t.Errorf("Float64LessNaNFirst misorders NaNs")
+	}
+}
+
+func TestSortFloat64DescNaNFirst(t *testing.T) {
+	negNaN := math.Copysign(math.NaN(), -1)
+	a := make([]float64, testSize)
+	for i := range a {
+		switch i % 10 {
+		case 0:
+			a[i] = math.NaN()
+		case 1:
+			a[i] = negNaN
+		case 2:
+			a[i] = math.Inf(1)
+		case 3:
+			a[i] = math.Copysign(0, -1)
+		default:
+			a[i] = float64s[i%len(float64s)]
+		}
+	}
+	nans := 0
+	for _, f := range a {
+		if math.IsNaN(f) {
+			nans++
+		}
+	}
+	SortFloat64DescNaNFirst(a)
+	if !Float64sAreSortedDescNaNFirst(a) {
+		t.Fatalf("not sorted descending, NaNs first")
+	}
+	for i := range a {
+		if math.IsNaN(a[i]) != (i < nans) {
+			t.Fatalf("a[%d] is %v; want the first %d to be NaN", i, a[i], nans)
+		}
+		if i > nans && a[i] > a[i-1] {
+			t.Fatalf("%v sorted after %v", a[i], a[i-1])
+		}
+	}
+	if !math.IsInf(a[nans], 1) || !math.IsInf(a[len(a)-1], -1) {
+		t.Errorf("+Inf isn't first after the NaNs or -Inf isn't last: %v, %v", a[nans], a[len(a)-1])
+	}
+}
@@ -0,0 +1,156 @@
+// Copyright 2015 Randall Farmer. All rights reserved.
+
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+package sortutil
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/twotwotwo/sorts"
+)
+
+func isDigit(c byte) bool { return '0' <= c && c <= '9' }
+
+// digitRun returns the run of digits at the start of s.
+func digitRun(s string) string {
+	i := 0
+	for i < len(s) && isDigit(s[i]) {
+		i++
+	}
+	return s[:i]
+}
+
+// naturalCompare compares a and b in natural order, returning -1, 0, or 1:
+// runs of digits compare as numbers, everything else byte by byte, and a
+// digit run compares to another byte as its first digit would.  Numbers of
+// any length compare without overflow.  Strings that differ only in
+// leading zeros are ordered by the first run whose zeros differ, fewer
+// zeros first.
+func naturalCompare(a, b string) int {
+	zeros := 0
+	for len(a) > 0 && len(b) > 0 {
+		if !isDigit(a[0]) || !isDigit(b[0]) {
+			if a[0] != b[0] {
+				if a[0] < b[0] {
+					return -1
+				}
+				return 1
+			}
+			a, b = a[1:], b[1:]
+			continue
+		}
+		ra, rb := digitRun(a), digitRun(b)
+		a, b = a[len(ra):], b[len(rb):]
+		na, nb := strings.TrimLeft(ra, "0"), strings.TrimLeft(rb, "0")
+		if len(na) != len(nb) {
+			if len(na) < len(nb) {
+				return -1
+			}
+			return 1
+		}
+		if c := strings.Compare(na, nb); c != 0 {
+			return c
+		}
+		if zeros == 0 && len(ra) != len(rb) {
+			zeros = -1
+			if len(ra) > len(rb) {
+				zeros = 1
+			}
+		}
+	}
+	switch {
+	case len(a) < len(b):
+		return -1
+	case len(a) > len(b):
+		return 1
+	}
+	return zeros
+}
+
+// NaturalLess reports whether a sorts before b in natural order, where
+// runs of digits compare as numbers, so "img2" sorts before "img10".
+// Numbers are compared digit by digit, so any length works; equal numbers
+// with more leading zeros sort after ones with fewer, if the strings are
+// otherwise equal.
+func NaturalLess(a, b string) bool { return naturalCompare(a, b) < 0 }
+
+// maxKeyDigits is the longest number (without leading zeros) naturalKey
+// encodes; it marks longer ones with a '9' and ends the key there.
+const maxKeyDigits = 8
+
+// naturalKey makes a radix sort key for s that sorts the way NaturalLess
+// does wherever keys differ: each digit run becomes a length digit, '0'
+// plus the number of digits after leading zeros, then those digits.  A
+// length digit compares to other bytes as any digit would, and numbers
+// with more digits compare higher.  Numbers too long to encode end the key
+// in '9', leaving strings whose keys tie to NaturalLess.
+func naturalKey(s string) string {
+	if strings.IndexAny(s, "0123456789") < 0 {
+		return s
+	}
+	var key []byte
+	for len(s) > 0 {
+		if !isDigit(s[0]) {
+			key = append(key, s[0])
+			s = s[1:]
+			continue
+		}
+		run := digitRun(s)
+		s = s[len(run):]
+		n := strings.TrimLeft(run, "0")
+		if len(n) > maxKeyDigits {
+			return string(append(key, '9'))
+		}
+		key = append(key, '0'+byte(len(n)))
+		key = append(key, n...)
+	}
+	return string(key)
+}
+
+// NaturalStringSlice sorts Strings in natural order, as NaturalLess
+// defines it, by radix sorting precomputed Keys that encode each number's
+// length ahead of its digits, then breaking ties with NaturalLess.  It
+// implements sorts.StringInterface; swaps move Strings and Keys together.
+type NaturalStringSlice struct {
+	Strings []string
+	Keys    []string
+}
+
+// NewNaturalStringSlice makes the keys to sort a in natural order.  Keys
+// for strings with digits take an allocation each.
+func NewNaturalStringSlice(a []string) NaturalStringSlice {
+	keys := make([]string, len(a))
+	for i, s := range a {
+		keys[i] = naturalKey(s)
+	}
+	return NaturalStringSlice{a, keys}
+}
+
+func (p NaturalStringSlice) Len() int { return len(p.Strings) }
+func (p NaturalStringSlice) Less(i, j int) bool {
+	if p.Keys[i] != p.Keys[j] {
+		return p.Keys[i] < p.Keys[j]
+	}
+	return NaturalLess(p.Strings[i], p.Strings[j])
+}
+func (p NaturalStringSlice) Swap(i, j int) {
+	p.Strings[i], p.Strings[j] = p.Strings[j], p.Strings[i]
+	p.Keys[i], p.Keys[j] = p.Keys[j], p.Keys[i]
+}
+
+// Key returns the natural sort key of string i.
+func (p NaturalStringSlice) Key(i int) string { return p.Keys[i] }
+
+// Sort is a convenience method.
+func (p NaturalStringSlice) Sort() { sorts.ByString(p) }
+
+// SortNatural sorts a in natural order, so "img2" sorts before "img10".
+func SortNatural(a []string) { NewNaturalStringSlice(a).Sort() }
+
+// NaturalAreSorted tests whether a is sorted in natural order.
+func NaturalAreSorted(a []string) bool {
+	return sort.SliceIsSorted(a, func(i, j int) bool { return NaturalLess(a[i], a[j]) })
+}
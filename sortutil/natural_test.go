@@ -0,0 +1,62 @@
+// Copyright 2015 Randall Farmer. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sortutil_test
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+
+	. "github.com/twotwotwo/sorts/sortutil"
+)
+
+func TestSortNatural(t *testing.T) {
+	a := []string{"img10", "img2", "img1", "img02", "img", "img-1", "x99999999999999999999", "x100000000000000000000", "x9", "", "007"}
+	SortNatural(a)
+	want := []string{"", "007", "img", "img-1", "img1", "img2", "img02", "img10", "x9", "x99999999999999999999", "x100000000000000000000"}
+	for i := range want {
+		if a[i] != want[i] {
+			t.Fatalf("got %q, want %q", a, want)
+		}
+	}
+}
+
+// randomNatural makes short strings mixing digits, zeros, and bytes on
+// both sides of the digits.
+func randomNatural() string {
+	const chars = "0019a-z"
+	b := make([]byte, rand.Intn(12))
+	for i := range b {
+		b[i] = chars[rand.Intn(len(chars))]
+	}
+	return string(b)
+}
+
+func TestNaturalKeys(t *testing.T) {
+	a := make([]string, testSize)
+	for i := range a {
+		a[i] = randomNatural()
+	}
+	p := NewNaturalStringSlice(append([]string(nil), a...))
+	for i := 0; i < 100000; i++ {
+		x, y := rand.Intn(len(a)), rand.Intn(len(a))
+		if p.Keys[x] < p.Keys[y] && !NaturalLess(p.Strings[x], p.Strings[y]) {
+			t.Fatalf("key of %q (%q) is less than key of %q (%q), but the string isn't", p.Strings[x], p.Keys[x], p.Strings[y], p.Keys[y])
+		}
+		if NaturalLess(p.Strings[x], p.Strings[y]) == NaturalLess(p.Strings[y], p.Strings[x]) && p.Strings[x] != p.Strings[y] {
+			t.Fatalf("%q and %q aren't ordered", p.Strings[x], p.Strings[y])
+		}
+	}
+	p.Sort()
+	if !NaturalAreSorted(p.Strings) {
+		t.Errorf("didn't sort")
+	}
+	sort.Slice(a, func(i, j int) bool { return NaturalLess(a[i], a[j]) })
+	for i := range a {
+		if a[i] != p.Strings[i] {
+			t.Fatalf("NaturalStringSlice and sort.Slice with NaturalLess disagree at %d", i)
+		}
+	}
+}
@@ -0,0 +1,122 @@
+// Copyright 2015 Randall Farmer. All rights reserved.
+
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+package sortutil
+
+import (
+	"math"
+	"sort"
+
+	"github.com/twotwotwo/sorts"
+)
+
+// PtrIntSlice attaches the methods of Int64Interface to []*int, sorting
+// by the pointed-to ints in increasing order, nils first.  Nils get the
+// same key as math.MinInt64, and Less puts them before it.
+type PtrIntSlice []*int
+
+func (p PtrIntSlice) Len() int { return len(p) }
+func (p PtrIntSlice) Less(i, j int) bool {
+	return p[j] != nil && (p[i] == nil || *p[i] < *p[j])
+}
+func (p PtrIntSlice) Swap(i, j int) { p[i], p[j] = p[j], p[i] }
+
+// Key produces a radix sort key for an item, math.MinInt64 if it's nil.
+func (p PtrIntSlice) Key(i int) int64 {
+	if p[i] == nil {
+		return math.MinInt64
+	}
+	return int64(*p[i])
+}
+
+// Sort is a convenience method.
+func (p PtrIntSlice) Sort() { sorts.ByInt64(p) }
+
+// PtrStringSlice attaches the methods of StringInterface to []*string,
+// sorting by the pointed-to strings in increasing order, nils first.  Nils
+// get the same key as "", and Less puts them before it.
+type PtrStringSlice []*string
+
+func (p PtrStringSlice) Len() int { return len(p) }
+func (p PtrStringSlice) Less(i, j int) bool {
+	return p[j] != nil && (p[i] == nil || *p[i] < *p[j])
+}
+func (p PtrStringSlice) Swap(i, j int) { p[i], p[j] = p[j], p[i] }
+
+// Key returns the string an item points to, or "" if it's nil.
+func (p PtrStringSlice) Key(i int) string {
+	if p[i] == nil {
+		return ""
+	}
+	return *p[i]
+}
+
+// Sort is a convenience method.
+func (p PtrStringSlice) Sort() { sorts.ByString(p) }
+
+// PtrInts sorts a slice of int pointers by the ints in increasing order,
+// with nils first, or last if nilsLast is set.
+func PtrInts(a []*int, nilsLast bool) {
+	PtrIntSlice(a).Sort()
+	if nilsLast {
+		moveNilsLast(a)
+	}
+}
+
+// PtrStrings sorts a slice of string pointers by the strings in increasing
+// order, with nils first, or last if nilsLast is set.
+func PtrStrings(a []*string, nilsLast bool) {
+	PtrStringSlice(a).Sort()
+	if nilsLast {
+		moveNilsLast(a)
+	}
+}
+
+// PtrIntsAreSorted tests whether a slice of int pointers is sorted by the
+// ints in increasing order, with nils first, or last if nilsLast is set.
+func PtrIntsAreSorted(a []*int, nilsLast bool) bool {
+	a, ok := trimNils(a, nilsLast)
+	return ok && sort.IsSorted(PtrIntSlice(a))
+}
+
+// PtrStringsAreSorted tests whether a slice of string pointers is sorted
+// by the strings in increasing order, with nils first, or last if nilsLast
+// is set.
+func PtrStringsAreSorted(a []*string, nilsLast bool) bool {
+	a, ok := trimNils(a, nilsLast)
+	return ok && sort.IsSorted(PtrStringSlice(a))
+}
+
+// moveNilsLast moves the nils at the start of a sorted a to its end,
+// keeping the rest in order.
+func moveNilsLast[T any](a []*T) {
+	n := 0
+	for n < len(a) && a[n] == nil {
+		n++
+	}
+	if n == 0 {
+		return
+	}
+	copy(a, a[n:])
+	clear(a[len(a)-n:])
+}
+
+// trimNils, if nilsLast is set, trims the nils off the end of a, and
+// reports whether there are none left elsewhere, so what's left can be
+// checked with the nils-first Less.
+func trimNils[T any](a []*T, nilsLast bool) ([]*T, bool) {
+	if !nilsLast {
+		return a, true
+	}
+	for len(a) > 0 && a[len(a)-1] == nil {
+		a = a[:len(a)-1]
+	}
+	for _, p := range a {
+		if p == nil {
+			return a, false
+		}
+	}
+	return a, true
+}
@@ -0,0 +1,71 @@
+// Copyright 2015 Randall Farmer. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sortutil_test
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	. "github.com/twotwotwo/sorts/sortutil"
+)
+
+func TestPtrInts(t *testing.T) {
+	for _, nilsLast := range []bool{false, true} {
+		a := make([]*int, testSize)
+		nils := 0
+		for i := range a {
+			switch rand.Intn(8) {
+			case 0:
+				nils++
+			case 1:
+				v := math.MinInt64
+				a[i] = &v
+			default:
+				v := ints[i%len(ints)]
+				a[i] = &v
+			}
+		}
+		PtrInts(a, nilsLast)
+		if !PtrIntsAreSorted(a, nilsLast) {
+			t.Errorf("nilsLast=%v: didn't sort", nilsLast)
+		}
+		rest := a[nils:]
+		if nilsLast {
+			rest = a[:len(a)-nils]
+		}
+		for _, p := range rest {
+			if p == nil {
+				t.Fatalf("nilsLast=%v: nils weren't all together at the end", nilsLast)
+			}
+		}
+	}
+	if PtrIntsAreSorted([]*int{nil, new(int)}, true) {
+		t.Errorf("PtrIntsAreSorted with nilsLast accepted a leading nil")
+	}
+}
+
+func TestPtrStrings(t *testing.T) {
+	for _, nilsLast := range []bool{false, true} {
+		a := make([]*string, testSize)
+		nils := 0
+		for i := range a {
+			if rand.Intn(8) == 0 {
+				nils++
+				continue
+			}
+			s := strings[i%len(strings)] // includes ""
+			a[i] = &s
+		}
+		PtrStrings(a, nilsLast)
+		if !PtrStringsAreSorted(a, nilsLast) {
+			t.Errorf("nilsLast=%v: didn't sort", nilsLast)
+		}
+		first, last := a[0], a[len(a)-1]
+		if (nilsLast && (last != nil || *first != "")) || (!nilsLast && (first != nil || *a[nils] != "")) {
+			t.Errorf("nilsLast=%v: nils or empty strings out of place", nilsLast)
+		}
+	}
+}
@@ -0,0 +1,43 @@
+// Copyright 2015 Randall Farmer. All rights reserved.
+
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+package sortutil
+
+import "slices"
+
+// The Reverse* funcs do what sorts.Flip does for a slice's Slice type,
+// but index the slice directly, through slices.Reverse, instead of calling
+// Swap for each pair.  After sorting a large slice ascending to get it
+// descending, they're noticeably faster.
+
+// ReverseInts reverses the order of a slice of ints in place.
+func ReverseInts(a []int) { slices.Reverse(a) }
+
+// ReverseInt32s reverses the order of a slice of int32s in place.
+func ReverseInt32s(a []int32) { slices.Reverse(a) }
+
+// ReverseInt64s reverses the order of a slice of int64s in place.
+func ReverseInt64s(a []int64) { slices.Reverse(a) }
+
+// ReverseUints reverses the order of a slice of uints in place.
+func ReverseUints(a []uint) { slices.Reverse(a) }
+
+// ReverseUint32s reverses the order of a slice of uint32s in place.
+func ReverseUint32s(a []uint32) { slices.Reverse(a) }
+
+// ReverseUint64s reverses the order of a slice of uint64s in place.
+func ReverseUint64s(a []uint64) { slices.Reverse(a) }
+
+// ReverseFloat32s reverses the order of a slice of float32s in place.
+func ReverseFloat32s(a []float32) { slices.Reverse(a) }
+
+// ReverseFloat64s reverses the order of a slice of float64s in place.
+func ReverseFloat64s(a []float64) { slices.Reverse(a) }
+
+// ReverseStrings reverses the order of a slice of strings in place.
+func ReverseStrings(a []string) { slices.Reverse(a) }
+
+// ReverseBytes reverses the order of a slice of byte slices in place.
+func ReverseBytes(a [][]byte) { slices.Reverse(a) }
@@ -0,0 +1,48 @@
+// Copyright 2015 Randall Farmer. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sortutil_test
+
+import (
+	"reflect"
+	"testing"
+
+	. "github.com/twotwotwo/sorts/sortutil"
+)
+
+func TestReverse(t *testing.T) {
+	for l := 0; l < 5; l++ {
+		a, want := make([]int, l), make([]int, l)
+		for i := range a {
+			a[i], want[l-1-i] = i, i
+		}
+		ReverseInts(a)
+		if !reflect.DeepEqual(a, want) {
+			t.Errorf("ReverseInts gave %v, want %v", a, want)
+		}
+	}
+
+	s := []string{"a", "b", "c"}
+	ReverseStrings(s)
+	if !reflect.DeepEqual(s, []string{"c", "b", "a"}) {
+		t.Errorf("ReverseStrings gave %q", s)
+	}
+
+	f := append([]float64(nil), float64s[:]...)
+	Float64s(f)
+	ReverseFloat64s(f)
+	for i := 1; i < len(f); i++ {
+		if Float64Less(f[i-1], f[i]) {
+			t.Errorf("ReverseFloat64s left %v before %v", f[i-1], f[i])
+		}
+	}
+}
+
+func BenchmarkReverseUint64s1e6(b *testing.B) {
+	a := make([]uint64, 1e6)
+	b.SetBytes(8 * 1e6)
+	for i := 0; i < b.N; i++ {
+		ReverseUint64s(a)
+	}
+}
@@ -0,0 +1,108 @@
+// Copyright 2015 Randall Farmer. All rights reserved.
+
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+package sortutil
+
+import (
+	"sort"
+	"unicode/utf8"
+
+	"github.com/twotwotwo/sorts"
+)
+
+// invalidRuneBase is added to each byte of invalid UTF-8 to get a value
+// past the end of Unicode to sort it by.
+const invalidRuneBase = utf8.MaxRune + 1
+
+// decodeRune is utf8.DecodeRuneInString, except a byte of invalid UTF-8
+// comes back as invalidRuneBase plus the byte value, not utf8.RuneError.
+func decodeRune(s string) (rune, int) {
+	r, size := utf8.DecodeRuneInString(s)
+	if r == utf8.RuneError && size == 1 {
+		return invalidRuneBase + rune(s[0]), 1
+	}
+	return r, size
+}
+
+// RuneStringLess compares strings code point by code point.  For valid
+// UTF-8 that's the same as comparing bytes.  Each byte of invalid UTF-8
+// counts as a code point past the end of Unicode, so it sorts after all
+// valid code points (including a real U+FFFD, utf8.RuneError), and invalid
+// bytes sort by their value.  Only identical strings compare equal.
+func RuneStringLess(s, t string) bool {
+	for len(s) > 0 && len(t) > 0 {
+		r1, size1 := decodeRune(s)
+		r2, size2 := decodeRune(t)
+		if r1 != r2 {
+			return r1 < r2
+		}
+		s, t = s[size1:], t[size2:]
+	}
+	return len(t) > 0
+}
+
+// RuneStringKey packs the first two code points of s, decoded as in
+// RuneStringLess, into a uint64 key.
+func RuneStringKey(s string) uint64 {
+	k := uint64(0)
+	for shift := uint(32); ; shift -= 32 {
+		if len(s) > 0 {
+			r, size := decodeRune(s)
+			k |= uint64(r+1) << shift // +1 so an absent rune sorts first
+			s = s[size:]
+		}
+		if shift == 0 {
+			return k
+		}
+	}
+}
+
+// RuneStringSlice attaches the methods of Uint64Interface to []string,
+// sorting in increasing order by code point, with invalid UTF-8 handled
+// as in RuneStringLess.  Use StringSlice unless you need invalid UTF-8 to
+// sort that way; it's faster.
+type RuneStringSlice []string
+
+func (p RuneStringSlice) Len() int           { return len(p) }
+func (p RuneStringSlice) Less(i, j int) bool { return RuneStringLess(p[i], p[j]) }
+func (p RuneStringSlice) Swap(i, j int)      { p[i], p[j] = p[j], p[i] }
+
+// Key produces a radix sort key from a string's first two code points.
+func (p RuneStringSlice) Key(i int) uint64 { return RuneStringKey(p[i]) }
+
+// Sort is a convenience method.
+func (p RuneStringSlice) Sort() { sorts.ByUint64(p) }
+
+// RuneStrings sorts a slice of strings in increasing order by code point.
+func RuneStrings(a []string) { RuneStringSlice(a).Sort() }
+
+// RuneStringsAreSorted tests whether a slice of strings is sorted in
+// increasing order by code point.
+func RuneStringsAreSorted(a []string) bool { return sort.IsSorted(RuneStringSlice(a)) }
+
+// SearchRuneStrings searches strings sorted by code point; read about
+// sort.Search for more.
+func SearchRuneStrings(a []string, x string) int {
+	return sort.Search(len(a), func(i int) bool { return !RuneStringLess(a[i], x) })
+}
+
+// Search returns the result of applying SearchRuneStrings to the receiver
+// and x.
+func (p RuneStringSlice) Search(x string) int { return SearchRuneStrings(p, x) }
+
+// RuneSlice is Int32Slice under the name for []rune: it sorts runes by
+// code point, through ByInt64 so negative values (which aren't valid code
+// points, but can turn up) sort first rather than last.
+type RuneSlice = Int32Slice
+
+// Runes sorts a slice of runes in increasing order by code point.
+func Runes(a []rune) { Int32Slice(a).Sort() }
+
+// RunesAreSorted tests whether a slice of runes is sorted in increasing
+// order by code point.
+func RunesAreSorted(a []rune) bool { return Int32sAreSorted(a) }
+
+// SearchRunes searches runes; read about sort.Search for more.
+func SearchRunes(a []rune, x rune) int { return SearchInt32s(a, x) }
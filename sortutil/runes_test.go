@@ -0,0 +1,71 @@
+// Copyright 2015 Randall Farmer. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sortutil_test
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+	"unicode/utf8"
+
+	. "github.com/twotwotwo/sorts/sortutil"
+)
+
+var runeStrings = [...]string{"", "a", "ab", "a\x00", "é", "é", "�", "\xff", "\xfe", "a\xff", "日本", "日", "\U0010FFFF", "z"}
+
+func TestSortRuneStringSlice(t *testing.T) {
+	a := make(RuneStringSlice, testSize)
+	for i := range a {
+		a[i] = runeStrings[i%len(runeStrings)]
+	}
+	a.Sort()
+	if !sort.IsSorted(a) {
+		t.Errorf("got %q", a)
+	}
+	// valid UTF-8 sorts like bytes; invalid sorts after all valid
+	// strings, in byte order
+	want := []string{"", "a", "a\x00", "ab", "a\xff", "é", "z", "é", "日", "日本", "�", "\U0010FFFF", "\xfe", "\xff"}
+	j := 0
+	for i := range a {
+		if i > 0 && a[i] == a[i-1] {
+			continue
+		}
+		if a[i] != want[j] {
+			t.Fatalf("item %d is %q, want %q", j, a[i], want[j])
+		}
+		j++
+	}
+	if a.Search("") != 0 || a.Search("\xff\xff") != len(a) || a[a.Search("\xff")] != "\xff" {
+		t.Errorf("search failed")
+	}
+	for i := 1; i < len(want); i++ {
+		if RuneStringKey(want[i]) < RuneStringKey(want[i-1]) {
+			t.Errorf("RuneStringKey disagrees with RuneStringLess on %q, %q", want[i-1], want[i])
+		}
+	}
+}
+
+func TestRuneStrings(t *testing.T) {
+	data := runeStrings
+	RuneStrings(data[:])
+	if !RuneStringsAreSorted(data[:]) {
+		t.Errorf("got %q", data)
+	}
+}
+
+func TestRunes(t *testing.T) {
+	a := make([]rune, testSize)
+	for i := range a {
+		a[i] = rand.Int31n(utf8.MaxRune + 1)
+	}
+	a[0], a[1] = utf8.RuneError, -1
+	Runes(a)
+	if !RunesAreSorted(a) || a[0] != -1 {
+		t.Errorf("runes didn't sort")
+	}
+	if i := RuneSlice(a).Search(utf8.RuneError); a[i] != utf8.RuneError || SearchRunes(a, utf8.RuneError) != i {
+		t.Errorf("Search(RuneError) found %d", i)
+	}
+}
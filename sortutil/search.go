@@ -0,0 +1,128 @@
+// Copyright 2015 Randall Farmer. All rights reserved.
+
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+package sortutil
+
+import (
+	"bytes"
+	"sort"
+)
+
+// SearchIntExact searches ints sorted in increasing order for x, returning
+// the index of the first item >= x (as SearchInts does) and whether that
+// item equals x.
+func SearchIntExact(a []int, x int) (i int, found bool) {
+	i = SearchInts(a, x)
+	return i, i < len(a) && a[i] == x
+}
+
+// SearchInt32Exact is SearchIntExact for int32s.
+func SearchInt32Exact(a []int32, x int32) (i int, found bool) {
+	i = SearchInt32s(a, x)
+	return i, i < len(a) && a[i] == x
+}
+
+// SearchInt64Exact is SearchIntExact for int64s.
+func SearchInt64Exact(a []int64, x int64) (i int, found bool) {
+	i = SearchInt64s(a, x)
+	return i, i < len(a) && a[i] == x
+}
+
+// SearchUintExact is SearchIntExact for uints.
+func SearchUintExact(a []uint, x uint) (i int, found bool) {
+	i = SearchUints(a, x)
+	return i, i < len(a) && a[i] == x
+}
+
+// SearchUint32Exact is SearchIntExact for uint32s.
+func SearchUint32Exact(a []uint32, x uint32) (i int, found bool) {
+	i = SearchUint32s(a, x)
+	return i, i < len(a) && a[i] == x
+}
+
+// SearchUint64Exact is SearchIntExact for uint64s.
+func SearchUint64Exact(a []uint64, x uint64) (i int, found bool) {
+	i = SearchUint64s(a, x)
+	return i, i < len(a) && a[i] == x
+}
+
+// SearchFloat32Exact is SearchIntExact for float32s.  NaN finds NaN, and
+// -0 and +0 are distinct, as in Float32Less.
+func SearchFloat32Exact(a []float32, x float32) (i int, found bool) {
+	i = SearchFloat32s(a, x)
+	return i, i < len(a) && Float32Key(a[i]) == Float32Key(x)
+}
+
+// SearchFloat64Exact is SearchIntExact for float64s.  NaN finds NaN, and
+// -0 and +0 are distinct, as in Float64Less.
+func SearchFloat64Exact(a []float64, x float64) (i int, found bool) {
+	i = SearchFloat64s(a, x)
+	return i, i < len(a) && Float64Key(a[i]) == Float64Key(x)
+}
+
+// SearchStringExact is SearchIntExact for strings.
+func SearchStringExact(a []string, x string) (i int, found bool) {
+	i = SearchStrings(a, x)
+	return i, i < len(a) && a[i] == x
+}
+
+// SearchBytesExact is SearchIntExact for byte slices.
+func SearchBytesExact(a [][]byte, x []byte) (i int, found bool) {
+	i = SearchBytes(a, x)
+	return i, i < len(a) && bytes.Equal(a[i], x)
+}
+
+// SearchIntsLast searches ints sorted in increasing order for the first
+// item > x, returning len(a) if there is none.  With SearchInts, it
+// brackets the run of items equal to x: they're a[SearchInts(a, x):
+// SearchIntsLast(a, x)].
+func SearchIntsLast(a []int, x int) int {
+	return sort.Search(len(a), func(i int) bool { return a[i] > x })
+}
+
+// SearchInt32sLast is SearchIntsLast for int32s.
+func SearchInt32sLast(a []int32, x int32) int {
+	return sort.Search(len(a), func(i int) bool { return a[i] > x })
+}
+
+// SearchInt64sLast is SearchIntsLast for int64s.
+func SearchInt64sLast(a []int64, x int64) int {
+	return sort.Search(len(a), func(i int) bool { return a[i] > x })
+}
+
+// SearchUintsLast is SearchIntsLast for uints.
+func SearchUintsLast(a []uint, x uint) int {
+	return sort.Search(len(a), func(i int) bool { return a[i] > x })
+}
+
+// SearchUint32sLast is SearchIntsLast for uint32s.
+func SearchUint32sLast(a []uint32, x uint32) int {
+	return sort.Search(len(a), func(i int) bool { return a[i] > x })
+}
+
+// SearchUint64sLast is SearchIntsLast for uint64s.
+func SearchUint64sLast(a []uint64, x uint64) int {
+	return sort.Search(len(a), func(i int) bool { return a[i] > x })
+}
+
+// SearchFloat32sLast is SearchIntsLast for float32s.
+func SearchFloat32sLast(a []float32, x float32) int {
+	return sort.Search(len(a), func(i int) bool { return Float32Key(a[i]) > Float32Key(x) })
+}
+
+// SearchFloat64sLast is SearchIntsLast for float64s.
+func SearchFloat64sLast(a []float64, x float64) int {
+	return sort.Search(len(a), func(i int) bool { return Float64Key(a[i]) > Float64Key(x) })
+}
+
+// SearchStringsLast is SearchIntsLast for strings.
+func SearchStringsLast(a []string, x string) int {
+	return sort.Search(len(a), func(i int) bool { return a[i] > x })
+}
+
+// SearchBytesLast is SearchIntsLast for byte slices.
+func SearchBytesLast(a [][]byte, x []byte) int {
+	return sort.Search(len(a), func(i int) bool { return bytes.Compare(a[i], x) > 0 })
+}
@@ -0,0 +1,69 @@
+// Copyright 2015 Randall Farmer. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sortutil_test
+
+import (
+	"math"
+	"testing"
+
+	. "github.com/twotwotwo/sorts/sortutil"
+)
+
+func TestSearchExact(t *testing.T) {
+	a := []int{-5, 0, 0, 3}
+	for _, c := range []struct {
+		x     int
+		i     int
+		found bool
+	}{{-9, 0, false}, {-5, 0, true}, {0, 1, true}, {1, 3, false}, {3, 3, true}, {4, 4, false}} {
+		if i, found := SearchIntExact(a, c.x); i != c.i || found != c.found {
+			t.Errorf("SearchIntExact(%d) = %d, %v; want %d, %v", c.x, i, found, c.i, c.found)
+		}
+	}
+	if _, found := SearchIntExact(nil, 0); found {
+		t.Errorf("found 0 in an empty slice")
+	}
+
+	f := []float64{math.Inf(-1), 0, 1.5, math.NaN()}
+	if i, found := SearchFloat64Exact(f, math.NaN()); i != 3 || !found {
+		t.Errorf("SearchFloat64Exact(NaN) = %d, %v", i, found)
+	}
+	if i, found := SearchFloat64Exact(f, 1); i != 2 || found {
+		t.Errorf("SearchFloat64Exact(1) = %d, %v", i, found)
+	}
+	if _, found := SearchStringExact([]string{"a", "b"}, "b"); !found {
+		t.Errorf("SearchStringExact didn't find b")
+	}
+	if _, found := SearchBytesExact([][]byte{[]byte("a")}, []byte("ab")); found {
+		t.Errorf("SearchBytesExact found ab")
+	}
+	if i, found := SearchUint64Exact([]uint64{1, 1<<64 - 1}, 1<<64-1); i != 1 || !found {
+		t.Errorf("SearchUint64Exact(max) = %d, %v", i, found)
+	}
+}
+
+func TestSearchLast(t *testing.T) {
+	a := []int{-5, 0, 0, 0, 3}
+	for _, x := range []int{-9, -5, 0, 1, 3, 4} {
+		first, last := SearchInts(a, x), SearchIntsLast(a, x)
+		for i, v := range a {
+			if (i >= first && i < last) != (v == x) {
+				t.Errorf("[%d,%d) isn't the run of %d in %v", first, last, x, a)
+				break
+			}
+		}
+	}
+	f := []float64{0, math.NaN(), math.NaN()}
+	if SearchFloat64sLast(f, math.NaN()) != 3 || SearchFloat64sLast(f, 0) != 1 {
+		t.Errorf("SearchFloat64sLast wrong")
+	}
+	s := []string{"a", "b", "b", "c"}
+	if SearchStringsLast(s, "b") != 3 || SearchStringsLast(s, "") != 0 {
+		t.Errorf("SearchStringsLast wrong")
+	}
+	if SearchBytesLast([][]byte{nil, []byte("a")}, nil) != 1 {
+		t.Errorf("SearchBytesLast wrong")
+	}
+}
@@ -0,0 +1,29 @@
+// Copyright 2015 Randall Farmer. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sortutil_test
+
+import (
+	"sort"
+	"testing"
+
+	. "github.com/twotwotwo/sorts/sortutil"
+)
+
+func TestStable(t *testing.T) {
+	a := make([]int, testSize)
+	f := make([]float64, testSize)
+	s := make([]string, testSize)
+	for i := range a {
+		a[i] = ints[i%len(ints)]
+		f[i] = float64s[i%len(float64s)]
+		s[i] = strings[i%len(strings)]
+	}
+	StableInts(a)
+	StableFloat64s(f)
+	StableStrings(s)
+	if !sort.IntsAreSorted(a) || !Float64sAreSorted(f) || !sort.StringsAreSorted(s) {
+		t.Errorf("stable sorts didn't sort")
+	}
+}
@@ -0,0 +1,97 @@
+// Copyright 2015 Randall Farmer. All rights reserved.
+
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+package sortutil
+
+import (
+	"sort"
+	"time"
+
+	"github.com/twotwotwo/sorts"
+)
+
+// TimeLess compares the wall-clock times of t and u.  Unlike t.Before(u),
+// it ignores monotonic clock readings, so it agrees with TimeKey.
+func TimeLess(t, u time.Time) bool {
+	ts, us := t.Unix(), u.Unix()
+	return ts < us || (ts == us && t.Nanosecond() < u.Nanosecond())
+}
+
+// maxNanoSecs bounds the times whose UnixNano fits in an int64.
+const maxNanoSecs = 1<<63/1000000000 - 1
+
+// TimeKey generates a uint64 key from a time's wall-clock reading, so that
+// earlier times get lower keys. Times too far from 1970 for UnixNano (before
+// 1678 or after 2262) all get the lowest or highest key, and are left for
+// TimeLess to sort.
+func TimeKey(t time.Time) uint64 {
+	s := t.Unix()
+	if s < -maxNanoSecs {
+		return 0
+	}
+	if s > maxNanoSecs {
+		return ^uint64(0)
+	}
+	return uint64(s*1e9+int64(t.Nanosecond())) ^ 1<<63
+}
+
+// TimeSlice attaches the methods of Uint64Interface to []time.Time, sorting
+// in increasing order by wall-clock time.
+type TimeSlice []time.Time
+
+func (p TimeSlice) Len() int           { return len(p) }
+func (p TimeSlice) Less(i, j int) bool { return TimeLess(p[i], p[j]) }
+func (p TimeSlice) Swap(i, j int)      { p[i], p[j] = p[j], p[i] }
+
+// Key produces a radix sort key for a time.
+func (p TimeSlice) Key(i int) uint64 { return TimeKey(p[i]) }
+
+// Sort is a convenience method.
+func (p TimeSlice) Sort() { sorts.ByUint64(p) }
+
+// Times sorts a slice of times in increasing order.
+func Times(a []time.Time) { TimeSlice(a).Sort() }
+
+// TimesAreSorted tests whether a slice of times is sorted in increasing
+// order.
+func TimesAreSorted(a []time.Time) bool { return sort.IsSorted(TimeSlice(a)) }
+
+// SearchTimes searches times; read about sort.Search for more.
+func SearchTimes(a []time.Time, x time.Time) int {
+	return sort.Search(len(a), func(i int) bool { return !TimeLess(a[i], x) })
+}
+
+// Search returns the result of applying SearchTimes to the receiver and x.
+func (p TimeSlice) Search(x time.Time) int { return SearchTimes(p, x) }
+
+// DurationSlice attaches the methods of Int64Interface to []time.Duration,
+// sorting in increasing order.
+type DurationSlice []time.Duration
+
+func (p DurationSlice) Len() int           { return len(p) }
+func (p DurationSlice) Less(i, j int) bool { return p[i] < p[j] }
+func (p DurationSlice) Swap(i, j int)      { p[i], p[j] = p[j], p[i] }
+
+// Key produces a radix sort key for a duration.
+func (p DurationSlice) Key(i int) int64 { return int64(p[i]) }
+
+// Sort is a convenience method.
+func (p DurationSlice) Sort() { sorts.ByInt64(p) }
+
+// Durations sorts a slice of durations in increasing order.
+func Durations(a []time.Duration) { DurationSlice(a).Sort() }
+
+// DurationsAreSorted tests whether a slice of durations is sorted in
+// increasing order.
+func DurationsAreSorted(a []time.Duration) bool { return sort.IsSorted(DurationSlice(a)) }
+
+// SearchDurations searches durations; read about sort.Search for more.
+func SearchDurations(a []time.Duration, x time.Duration) int {
+	return sort.Search(len(a), func(i int) bool { return a[i] >= x })
+}
+
+// Search returns the result of applying SearchDurations to the receiver
+// and x.
+func (p DurationSlice) Search(x time.Duration) int { return SearchDurations(p, x) }
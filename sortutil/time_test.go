@@ -0,0 +1,74 @@
+// Copyright 2015 Randall Farmer. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sortutil_test
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+	"time"
+
+	. "github.com/twotwotwo/sorts/sortutil"
+)
+
+func TestSortTimeSlice(t *testing.T) {
+	now := time.Now() // has a monotonic reading
+	data := []time.Time{
+		now,
+		now.Add(-time.Hour),
+		now.Round(0).Add(time.Second), // no monotonic reading
+		time.Unix(-1, 5),              // just before 1970
+		time.Unix(0, 0),
+		time.Date(1, 1, 1, 0, 0, 0, 0, time.UTC), // too early for UnixNano
+		time.Date(1, 1, 1, 0, 0, 0, 1, time.UTC),
+		time.Date(9999, 1, 1, 0, 0, 0, 0, time.UTC), // too late
+		time.Date(3000, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+	a := make(TimeSlice, testSize)
+	for i := range a {
+		a[i] = data[i%len(data)].Add(time.Duration(rand.Intn(3)))
+	}
+	a.Sort()
+	if !sort.IsSorted(a) {
+		t.Errorf("times didn't sort: %v", a)
+	}
+	for i := 1; i < len(a); i++ {
+		if a[i].Before(a[i-1]) && a[i].Round(0).Before(a[i-1].Round(0)) {
+			t.Errorf("%v sorted after %v", a[i], a[i-1])
+		}
+	}
+	if a.Search(time.Date(1, 1, 1, 0, 0, 0, 0, time.UTC)) != 0 || a.Search(time.Date(10000, 1, 1, 0, 0, 0, 0, time.UTC)) != len(a) {
+		t.Errorf("search failed")
+	}
+	if TimeKey(time.Unix(-1, 0)) >= TimeKey(time.Unix(0, 0)) {
+		t.Errorf("TimeKey puts 1969 after 1970")
+	}
+}
+
+func TestTimes(t *testing.T) {
+	data := []time.Time{time.Unix(5, 0), time.Unix(-5, 0), time.Unix(0, 0)}
+	Times(data)
+	if !TimesAreSorted(data) {
+		t.Errorf("got %v", data)
+	}
+}
+
+func TestDurations(t *testing.T) {
+	a := make([]time.Duration, testSize)
+	for i := range a {
+		a[i] = time.Duration(rand.Int63() - 1<<62)
+	}
+	a[0], a[1] = -time.Hour, time.Hour
+	Durations(a)
+	if !DurationsAreSorted(a) {
+		t.Errorf("durations didn't sort: %v", a)
+	}
+	if a[0] >= 0 {
+		t.Errorf("negative durations didn't sort first")
+	}
+	if i := DurationSlice(a).Search(time.Hour); a[i] != time.Hour {
+		t.Errorf("Search(time.Hour) found %v", a[i])
+	}
+}
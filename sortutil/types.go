@@ -15,9 +15,11 @@ import (
 	"sort"
 
 	"github.com/twotwotwo/sorts"
+	"github.com/twotwotwo/sorts/internal/floatkey"
 )
 
 // Float32Key generates a uint64 key from a float32. Use with Float32Less.
+// Keys follow Float64Key's order.
 func Float32Key(f float32) uint64 {
 	b := uint64(math.Float32bits(f)) << 32
 	b ^= ^(b>>63 - 1) | (1 << 63)
@@ -30,10 +32,13 @@ func Float32Less(f, g float32) bool {
 }
 
 // Float64Key generates a uint64 key from a float64. Use with Float64Less.
+// Keys are in numeric order, with subnormals in their place next to zero,
+// except that -0 gets the key just below +0's rather than an equal one, so
+// sorts put all -0s before all +0s.  NaNs with the sign bit clear, like
+// math.NaN(), get keys above +Inf's; NaNs with it set get keys below
+// -Inf's.
 func Float64Key(f float64) uint64 {
-	b := math.Float64bits(f)
-	b ^= ^(b>>63 - 1) | (1 << 63)
-	return b
+	return floatkey.Float64(f)
 }
 
 // Float64Less compares float64s, treating NaN as greater than all numbers.
@@ -327,3 +327,31 @@ func TestBytes(t *testing.T) {
 		t.Errorf("   got %v", data)
 	}
 }
+
+func TestFloatKeysNearZero(t *testing.T) {
+	negZero := math.Copysign(0, -1)
+	tiny := math.SmallestNonzeroFloat64
+	if Float64Key(0)-Float64Key(negZero) != 1 {
+		t.Errorf("-0 and +0 keys aren't adjacent")
+	}
+	if Float64Key(tiny)-Float64Key(0) != 1 || Float64Key(negZero)-Float64Key(-tiny) != 1 {
+		t.Errorf("smallest subnormals' keys aren't next to zero's")
+	}
+	maxSubnormal := math.Float64frombits(1<<52 - 1)
+	if !Float64Less(maxSubnormal, 0x1p-1022) || !Float64Less(-0x1p-1022, -maxSubnormal) {
+		t.Errorf("largest subnormal doesn't sort next to the smallest normal")
+	}
+	ordered := []float64{math.Copysign(math.NaN(), -1), math.Inf(-1), -1, -0x1p-1022, -tiny, negZero, 0, tiny, 0x1p-1022, 1, math.Inf(1), math.NaN()}
+	for i := 1; i < len(ordered); i++ {
+		if !Float64Less(ordered[i-1], ordered[i]) {
+			t.Errorf("%v didn't sort before %v", ordered[i-1], ordered[i])
+		}
+		f, g := float32(ordered[i-1]), float32(ordered[i])
+		if (f == g && math.Signbit(float64(f)) == math.Signbit(float64(g))) || math.IsNaN(float64(f)) && math.IsNaN(float64(g)) {
+			continue // collapsed to the same float32
+		}
+		if !Float32Less(f, g) {
+			t.Errorf("float32 %v didn't sort before %v", f, g)
+		}
+	}
+}
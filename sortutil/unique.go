@@ -0,0 +1,132 @@
+// Copyright 2015 Randall Farmer. All rights reserved.
+
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+package sortutil
+
+import "bytes"
+
+// The SortUnique* funcs sort a slice, then move one copy of each distinct
+// value to the front, returning that prefix.  The result shares a's
+// backing array, and what's left in a past it is the leftover duplicates
+// in no particular order; to free the memory they take, copy the result.
+
+// SortUniqueInts sorts a and returns its distinct values.
+func SortUniqueInts(a []int) []int {
+	Ints(a)
+	return compact(a)
+}
+
+// SortUniqueInt32s sorts a and returns its distinct values.
+func SortUniqueInt32s(a []int32) []int32 {
+	Int32s(a)
+	return compact(a)
+}
+
+// SortUniqueInt64s sorts a and returns its distinct values.
+func SortUniqueInt64s(a []int64) []int64 {
+	Int64s(a)
+	return compact(a)
+}
+
+// SortUniqueUints sorts a and returns its distinct values.
+func SortUniqueUints(a []uint) []uint {
+	Uints(a)
+	return compact(a)
+}
+
+// SortUniqueUint32s sorts a and returns its distinct values.
+func SortUniqueUint32s(a []uint32) []uint32 {
+	Uint32s(a)
+	return compact(a)
+}
+
+// SortUniqueUint64s sorts a and returns its distinct values.
+func SortUniqueUint64s(a []uint64) []uint64 {
+	Uint64s(a)
+	return compact(a)
+}
+
+// SortUniqueStrings sorts a and returns its distinct values.
+func SortUniqueStrings(a []string) []string {
+	Strings(a)
+	return compact(a)
+}
+
+// SortUniqueBytes sorts a and returns its distinct values.  The []byte
+// values themselves aren't copied.
+func SortUniqueBytes(a [][]byte) [][]byte {
+	Bytes(a)
+	if len(a) == 0 {
+		return a
+	}
+	n := 1
+	for i := 1; i < len(a); i++ {
+		if !bytes.Equal(a[i], a[n-1]) {
+			a[n], a[i] = a[i], a[n]
+			n++
+		}
+	}
+	return a[:n]
+}
+
+// The SortCount* funcs are SortUnique* that also count each distinct
+// value, returning counts parallel to values: a sort-based GROUP BY.
+// values shares a's backing array as SortUnique*'s results do.
+
+// SortCountInts sorts a and returns its distinct values and how many times
+// each appears.
+func SortCountInts(a []int) (values []int, counts []int) {
+	Ints(a)
+	return compactCount(a)
+}
+
+// SortCountUint64s sorts a and returns its distinct values and how many
+// times each appears.
+func SortCountUint64s(a []uint64) (values []uint64, counts []int) {
+	Uint64s(a)
+	return compactCount(a)
+}
+
+// SortCountStrings sorts a and returns its distinct values and how many
+// times each appears.
+func SortCountStrings(a []string) (values []string, counts []int) {
+	Strings(a)
+	return compactCount(a)
+}
+
+// compactCount is compact, also returning the length of each run.
+func compactCount[T comparable](a []T) ([]T, []int) {
+	if len(a) == 0 {
+		return a, nil
+	}
+	var counts []int
+	n, start := 1, 0
+	for i := 1; i < len(a); i++ {
+		if a[i] != a[n-1] {
+			counts = append(counts, i-start)
+			start = i
+			a[n], a[i] = a[i], a[n]
+			n++
+		}
+	}
+	counts = append(counts, len(a)-start)
+	return a[:n], counts
+}
+
+// compact moves the first of each run of equal values in a to the front,
+// and returns them.
+func compact[T comparable](a []T) []T {
+	if len(a) == 0 {
+		return a
+	}
+	n := 1
+	for i := 1; i < len(a); i++ {
+		if a[i] != a[n-1] {
+			a[n], a[i] = a[i], a[n]
+			n++
+		}
+	}
+	return a[:n]
+}
@@ -0,0 +1,75 @@
+// Copyright 2015 Randall Farmer. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sortutil_test
+
+import (
+	"sort"
+	"strconv"
+	"testing"
+
+	. "github.com/twotwotwo/sorts/sortutil"
+)
+
+func TestSortUnique(t *testing.T) {
+	a := make([]int, testSize)
+	s := make([]string, testSize)
+	b := make([][]byte, testSize)
+	want := map[int]bool{}
+	for i := range a {
+		a[i] = ints[i%len(ints)] % 100
+		s[i] = strconv.Itoa(a[i])
+		b[i] = []byte(s[i])
+		want[a[i]] = true
+	}
+	u := SortUniqueInts(a)
+	if len(u) != len(want) || !sort.IntsAreSorted(u) {
+		t.Fatalf("got %d sorted=%v, want %d sorted", len(u), sort.IntsAreSorted(u), len(want))
+	}
+	for i := 1; i < len(u); i++ {
+		if u[i] == u[i-1] {
+			t.Fatalf("duplicate %d", u[i])
+		}
+	}
+	if us := SortUniqueStrings(s); len(us) != len(want) || !sort.StringsAreSorted(us) {
+		t.Errorf("strings: got %d, want %d", len(us), len(want))
+	}
+	if ub := SortUniqueBytes(b); len(ub) != len(want) || !BytesAreSorted(ub) {
+		t.Errorf("bytes: got %d, want %d", len(ub), len(want))
+	}
+	if u := SortUniqueInts(nil); len(u) != 0 {
+		t.Errorf("nil: got %v", u)
+	}
+}
+
+func TestSortCount(t *testing.T) {
+	a := make([]int, testSize)
+	u := make([]uint64, testSize)
+	s := make([]string, testSize)
+	want := map[int]int{}
+	for i := range a {
+		a[i] = ints[i%len(ints)] % 100
+		u[i] = uint64(a[i] + 100)
+		s[i] = strconv.Itoa(a[i])
+		want[a[i]]++
+	}
+	values, counts := SortCountInts(a)
+	if len(values) != len(want) || len(counts) != len(values) || !sort.IntsAreSorted(values) {
+		t.Fatalf("got %d values, %d counts, want %d", len(values), len(counts), len(want))
+	}
+	for i, v := range values {
+		if counts[i] != want[v] {
+			t.Errorf("count of %d was %d, want %d", v, counts[i], want[v])
+		}
+	}
+	if uv, uc := SortCountUint64s(u); len(uv) != len(want) || uc[0] != want[int(uv[0])-100] {
+		t.Errorf("uint64s: got %v, %v", uv, uc)
+	}
+	if sv, sc := SortCountStrings(s); len(sv) != len(want) || len(sc) != len(want) {
+		t.Errorf("strings: got %d values, %d counts, want %d", len(sv), len(sc), len(want))
+	}
+	if v, c := SortCountInts(nil); len(v) != 0 || len(c) != 0 {
+		t.Errorf("SortCountInts(nil) returned %v, %v", v, c)
+	}
+}
@@ -0,0 +1,416 @@
+// Copyright 2009 The Go Authors.
+// Copyright 2015 Randall Farmer.
+// All rights reserved.
+
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+package sorts
+
+import "sort"
+
+// ByUint64Stable sorts data by a uint64 key, using Less to order items with
+// equal keys, and keeping items that are equal by both in their original
+// order.
+//
+// Unlike ByUint64, it isn't in-place: it copies the keys out, sorts them
+// with least-significant-digit radix passes (which are stable) alongside
+// their original positions, then moves data into place.  That costs about
+// 32 bytes of extra memory per item.  It isn't parallel.
+func ByUint64Stable(data Uint64Interface) {
+	l := data.Len()
+	if l < stableBlockSize {
+		stableSort(data, 0, l)
+		return
+	}
+	keys := make([]uint64, l)
+	for i := range keys {
+		keys[i] = data.Key(i)
+	}
+	stableByKeys(data, keys)
+
+	// check results!
+	checkUint64(data, 0, l)
+}
+
+// ByInt64Stable is the int64 counterpart of ByUint64Stable, with the same
+// memory cost.
+func ByInt64Stable(data Int64Interface) {
+	l := data.Len()
+	if l < stableBlockSize {
+		stableSort(data, 0, l)
+		return
+	}
+	keys := make([]uint64, l)
+	for i := range keys {
+		keys[i] = int64Key(data.Key(i))
+	}
+	stableByKeys(data, keys)
+
+	// check results!
+	checkInt64(data, 0, l)
+}
+
+// ByStringStable sorts data by a string key, using Less to order items with
+// equal keys, and keeping items that are equal by both in their original
+// order.
+//
+// Like ByUint64Stable, it isn't in-place: it calls Key once per item,
+// sorts the keys alongside their original positions (radix sorting where
+// ByString would, and merge sorting where ByString would quicksort), then
+// moves data into place.  That costs about 40 bytes of extra memory per
+// item.  It isn't parallel.
+func ByStringStable(data StringInterface) {
+	l := data.Len()
+	if l < stableBlockSize {
+		stableSort(data, 0, l)
+		return
+	}
+	sp := stringPerm{make([]string, l), make([]int, l)}
+	for i := range sp.keys {
+		sp.keys[i] = data.Key(i)
+		sp.perm[i] = i
+	}
+	scratch := stringPerm{make([]string, l), make([]int, l)}
+	radixSortStringStable(sp, scratch, 0, l, 0)
+	keys := sp.keys
+	applyPerm(data, sp.perm)
+	a := 0
+	for b := 1; b <= l; b++ {
+		if b == l || keys[b] != keys[a] {
+			if b-a > 1 {
+				stableSortEqualKeyRange(data, a, b)
+			}
+			a = b
+		}
+	}
+
+	// check results!
+	checkString(data, 0, l)
+}
+
+// stringPerm holds string keys and the original positions of the items
+// they came from.
+type stringPerm struct {
+	keys []string
+	perm []int
+}
+
+func (sp stringPerm) Len() int           { return len(sp.keys) }
+func (sp stringPerm) Less(i, j int) bool { return sp.keys[i] < sp.keys[j] }
+func (sp stringPerm) Swap(i, j int) {
+	sp.keys[i], sp.keys[j] = sp.keys[j], sp.keys[i]
+	sp.perm[i], sp.perm[j] = sp.perm[j], sp.perm[i]
+}
+func (sp stringPerm) Key(i int) string { return sp.keys[i] }
+
+// radixSortStringStable sorts sp[a:b], whose keys all share their first
+// offset bytes, with stable counting passes through scratch.  Keys too
+// short to have a byte at offset are all equal, so they stay put at the
+// start of the range.
+func radixSortStringStable(sp, scratch stringPerm, a, b, offset int) {
+	for {
+		if b-a < stableBlockSize || offset == maxRadixDepth {
+			stableSort(sp, a, b)
+			return
+		}
+
+		// bucket 0 is for too-short keys, the rest for each byte value
+		var bucketStarts, bucketEnds [257]int
+		for _, k := range sp.keys[a:b] {
+			if len(k) <= offset {
+				bucketStarts[0]++
+				continue
+			}
+			bucketStarts[int(k[offset])+1]++
+		}
+		if bucketStarts[0] == 0 {
+			sameBucket := false
+			for _, c := range bucketStarts[1:] {
+				if c == b-a {
+					sameBucket = true
+					break
+				}
+			}
+			if sameBucket {
+				// everything was in the same bucket
+				offset++
+				continue
+			}
+		}
+
+		pos := a
+		for i, c := range bucketStarts {
+			bucketStarts[i] = pos
+			pos += c
+			bucketEnds[i] = pos
+		}
+		for i := a; i < b; i++ {
+			k := sp.keys[i]
+			bucket := 0
+			if len(k) > offset {
+				bucket = int(k[offset]) + 1
+			}
+			scratch.keys[bucketStarts[bucket]] = k
+			scratch.perm[bucketStarts[bucket]] = sp.perm[i]
+			bucketStarts[bucket]++
+		}
+		copy(sp.keys[a:b], scratch.keys[a:b])
+		copy(sp.perm[a:b], scratch.perm[a:b])
+
+		pos = bucketEnds[0]
+		for _, end := range bucketEnds[1:] {
+			if end > pos+1 {
+				radixSortStringStable(sp, scratch, pos, end, offset+1)
+			}
+			pos = end
+		}
+		return
+	}
+}
+
+// stableByKeys stably sorts data by keys (reordering keys to match), then
+// uses data.Less to stably sort runs of equal keys.
+func stableByKeys(data sort.Interface, keys []uint64) {
+	perm := lsdSort(keys)
+	applyPerm(data, perm)
+	sortEqualKeyRuns(data, keys)
+}
+
+// sortEqualKeyRuns stably sorts each run of equal keys in data, which is
+// already sorted by keys, with data.Less.
+func sortEqualKeyRuns(data sort.Interface, keys []uint64) {
+	a := 0
+	for b := 1; b <= len(keys); b++ {
+		if b == len(keys) || keys[b] != keys[a] {
+			if b-a > 1 {
+				stableSortEqualKeyRange(data, a, b)
+			}
+			a = b
+		}
+	}
+}
+
+// lsdSort stably sorts keys with least-significant-digit radix passes, and
+// returns perm such that the key now at i was originally at perm[i].  It
+// skips digits that are the same in every key.  All scratch space is
+// allocated up front.
+func lsdSort(keys []uint64) (perm []int) {
+	l := len(keys)
+	perm = make([]int, l)
+	for i := range perm {
+		perm[i] = i
+	}
+	if l < 2 {
+		return perm
+	}
+
+	diff := uint64(0)
+	for _, k := range keys {
+		diff |= k ^ keys[0]
+	}
+
+	src, dst := keys, make([]uint64, l)
+	permSrc, permDst := perm, make([]int, l)
+	for shift := uint(0); diff>>shift != 0; shift += radix {
+		if (diff>>shift)&mask == 0 {
+			continue
+		}
+		var bucketStarts [1 << radix]int
+		for _, k := range src {
+			bucketStarts[(k>>shift)&mask]++
+		}
+		pos := 0
+		for i, c := range bucketStarts {
+			bucketStarts[i] = pos
+			pos += c
+		}
+		for i, k := range src {
+			d := (k >> shift) & mask
+			dst[bucketStarts[d]] = k
+			permDst[bucketStarts[d]] = permSrc[i]
+			bucketStarts[d]++
+		}
+		src, dst = dst, src
+		permSrc, permDst = permDst, permSrc
+	}
+	if &src[0] != &keys[0] {
+		copy(keys, src)
+	}
+	return permSrc
+}
+
+// applyPerm reorders data so the item now at perm[i] ends up at i.  It
+// follows each cycle of the permutation, so it makes the minimum number of
+// swaps: len(perm) minus the number of cycles.  It clobbers perm.
+func applyPerm(data sort.Interface, perm []int) {
+	for i := range perm {
+		if perm[i] == i {
+			continue
+		}
+		j, k := i, perm[i]
+		for k != i {
+			data.Swap(j, k)
+			perm[j] = j
+			j, k = k, perm[k]
+		}
+		perm[j] = j
+	}
+}
+
+// stableSortEqualKeyRange stable-sorts data[a:b] if it is not already
+// sorted.
+func stableSortEqualKeyRange(data sort.Interface, a, b int) {
+	for i := a; i < b-1; i++ {
+		if data.Less(i+1, i) {
+			stableSort(data, a, b)
+			return
+		}
+	}
+}
+
+// What follows is Go's sort.Stable, copied for the same reason as
+// quicksort is: to sort a range of data.
+
+// stableBlockSize is how big a block stableSort insertion-sorts before
+// merging.
+const stableBlockSize = 20
+
+// stableSort sorts data[a:b] stably, using O(n*log(n)) comparisons and
+// O(n*log(n)*log(n)) swaps, without allocating.
+func stableSort(data sort.Interface, lo, hi int) {
+	blockSize := stableBlockSize
+	a, b := lo, lo+blockSize
+	for b <= hi {
+		insertionSort(data, a, b)
+		a = b
+		b += blockSize
+	}
+	insertionSort(data, a, hi)
+
+	for blockSize < hi-lo {
+		a, b = lo, lo+2*blockSize
+		for b <= hi {
+			symMerge(data, a, a+blockSize, b)
+			a = b
+			b += 2 * blockSize
+		}
+		if m := a + blockSize; m < hi {
+			symMerge(data, a, m, hi)
+		}
+		blockSize *= 2
+	}
+}
+
+// symMerge merges the two sorted subsequences data[a:m] and data[m:b] using
+// the SymMerge algorithm from Pok-Son Kim and Arne Kutzner, "Stable Minimum
+// Storage Merging by Symmetric Comparisons", in Susanne Albers and Tomasz
+// Radzik, editors, Algorithms - ESA 2004, volume 3221 of Lecture Notes in
+// Computer Science, pages 714-723. Springer, 2004.
+func symMerge(data sort.Interface, a, m, b int) {
+	// Avoid unnecessary recursions of symMerge
+	// by direct insertion of data[a] into data[m:b]
+	// if data[a:m] only contains one element.
+	if m-a == 1 {
+		// Use binary search to find the lowest index i
+		// such that data[i] >= data[a] for m <= i < b.
+		// Exit the search loop with i == b in case no such index exists.
+		i := m
+		j := b
+		for i < j {
+			h := int(uint(i+j) >> 1)
+			if data.Less(h, a) {
+				i = h + 1
+			} else {
+				j = h
+			}
+		}
+		// Swap values until data[a] reaches the position before i.
+		for k := a; k < i-1; k++ {
+			data.Swap(k, k+1)
+		}
+		return
+	}
+
+	// Avoid unnecessary recursions of symMerge
+	// by direct insertion of data[m] into data[a:m]
+	// if data[m:b] only contains one element.
+	if b-m == 1 {
+		// Use binary search to find the lowest index i
+		// such that data[i] > data[m] for a <= i < m.
+		// Exit the search loop with i == m in case no such index exists.
+		i := a
+		j := m
+		for i < j {
+			h := int(uint(i+j) >> 1)
+			if !data.Less(m, h) {
+				i = h + 1
+			} else {
+				j = h
+			}
+		}
+		// Swap values until data[m] reaches the position i.
+		for k := m; k > i; k-- {
+			data.Swap(k, k-1)
+		}
+		return
+	}
+
+	mid := int(uint(a+b) >> 1)
+	n := mid + m
+	var start, r int
+	if m > mid {
+		start = n - b
+		r = mid
+	} else {
+		start = a
+		r = m
+	}
+	p := n - 1
+
+	for start < r {
+		c := int(uint(start+r) >> 1)
+		if !data.Less(p-c, c) {
+			start = c + 1
+		} else {
+			r = c
+		}
+	}
+
+	end := n - start
+	if start < m && m < end {
+		rotate(data, start, m, end)
+	}
+	if a < start && start < mid {
+		symMerge(data, a, start, mid)
+	}
+	if mid < end && end < b {
+		symMerge(data, mid, end, b)
+	}
+}
+
+// swapRange swaps data[a:a+n] with data[b:b+n].
+func swapRange(data sort.Interface, a, b, n int) {
+	for i := 0; i < n; i++ {
+		data.Swap(a+i, b+i)
+	}
+}
+
+// rotate rotates two consecutive blocks u = data[a:m] and v = data[m:b] in
+// data: data of the form 'x u v y' is changed to 'x v u y'.
+func rotate(data sort.Interface, a, m, b int) {
+	i := m - a
+	j := b - m
+
+	for i != j {
+		if i > j {
+			swapRange(data, m-i, m, j)
+			i -= j
+		} else {
+			swapRange(data, m-i, m+j-i, i)
+			j -= i
+		}
+	}
+	// i == j
+	swapRange(data, m-i, m, i)
+}
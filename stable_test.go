@@ -0,0 +1,143 @@
+// Copyright 2015 Randall Farmer. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sorts_test
+
+import (
+	"math/rand"
+	"strconv"
+	"testing"
+
+	. "github.com/twotwotwo/sorts"
+	. "github.com/twotwotwo/sorts/sortutil"
+)
+
+// seqRecord remembers where it started out, so we can check stability.
+type seqRecord struct {
+	Key int64
+	Seq int
+}
+
+// seqRecords sorts by Key only, so a stable sort must leave Seq ascending
+// among equal keys.
+type seqRecords []seqRecord
+
+func (s seqRecords) Len() int           { return len(s) }
+func (s seqRecords) Less(i, j int) bool { return s[i].Key < s[j].Key }
+func (s seqRecords) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+
+type uintSeqRecords struct{ seqRecords }
+
+func (s uintSeqRecords) Key(i int) uint64 { return uint64(s.seqRecords[i].Key) }
+
+type intSeqRecords struct{ seqRecords }
+
+func (s intSeqRecords) Key(i int) int64 { return s.seqRecords[i].Key }
+
+// makeSeqRecords makes n records with keys in [lo, lo+span).
+func makeSeqRecords(n int, lo, span int64) seqRecords {
+	s := make(seqRecords, n)
+	for i := range s {
+		s[i] = seqRecord{lo + rand.Int63n(span), i}
+	}
+	return s
+}
+
+func checkStable(t *testing.T, name string, s seqRecords) {
+	for i := 1; i < len(s); i++ {
+		if s[i].Key < s[i-1].Key {
+			t.Fatalf("%s: not sorted at %d", name, i)
+		}
+		if s[i].Key == s[i-1].Key && s[i].Seq < s[i-1].Seq {
+			t.Fatalf("%s: not stable at %d", name, i)
+		}
+	}
+}
+
+func TestByUint64Stable(t *testing.T) {
+	for _, n := range []int{0, 1, 2, 19, 20, 21, 1000, 100000} {
+		s := makeSeqRecords(n, 0, 100)
+		ByUint64Stable(uintSeqRecords{s})
+		checkStable(t, "narrow uints", s)
+
+		s = makeSeqRecords(n, 0, 1<<62)
+		for i := range s {
+			// share some keys even across a wide range
+			s[i].Key &^= 0xff0000
+		}
+		ByUint64Stable(uintSeqRecords{s})
+		checkStable(t, "wide uints", s)
+	}
+}
+
+func TestByInt64Stable(t *testing.T) {
+	for _, n := range []int{0, 1, 2, 19, 20, 21, 1000, 100000} {
+		s := makeSeqRecords(n, -50, 100)
+		ByInt64Stable(intSeqRecords{s})
+		checkStable(t, "ints", s)
+	}
+}
+
+func TestStableTiebreak(t *testing.T) {
+	uintData := make([]uint64, 1000)
+	for i := range uintData {
+		uintData[i] = uint64(rand.Int63n(100))
+	}
+	ByUint64Stable(RoundedKeyUint64s{Uint64Slice(uintData)})
+	if !Uint64sAreSorted(uintData) {
+		t.Errorf("stable sort didn't sort - 1K rounded uints")
+	}
+	intData := make([]int64, 1000)
+	for i := range intData {
+		intData[i] = rand.Int63n(100) - 50
+	}
+	ByInt64Stable(RoundedKeyInt64s{Int64Slice(intData)})
+	if !Int64sAreSorted(intData) {
+		t.Errorf("stable sort didn't sort - 1K rounded ints")
+	}
+}
+
+// seqStrings sorts by S only, so a stable sort must leave Seq ascending
+// among equal strings.
+type seqStrings []struct {
+	S   string
+	Seq int
+}
+
+func (s seqStrings) Len() int           { return len(s) }
+func (s seqStrings) Less(i, j int) bool { return s[i].S < s[j].S }
+func (s seqStrings) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+func (s seqStrings) Key(i int) string   { return s[i].S }
+
+func TestByStringStable(t *testing.T) {
+	long := string(make([]byte, 40)) // longer than maxRadixDepth
+	for _, n := range []int{0, 1, 2, 19, 20, 21, 1000, 100000} {
+		s := make(seqStrings, n)
+		for i := range s {
+			s[i].S = strconv.Itoa(rand.Intn(300))
+			if i%3 == 0 {
+				s[i].S = long + s[i].S
+			}
+			s[i].Seq = i
+		}
+		ByStringStable(s)
+		for i := 1; i < len(s); i++ {
+			if s[i].S < s[i-1].S {
+				t.Fatalf("n=%d: not sorted at %d", n, i)
+			}
+			if s[i].S == s[i-1].S && s[i].Seq < s[i-1].Seq {
+				t.Fatalf("n=%d: not stable at %d", n, i)
+			}
+		}
+	}
+
+	stringData := make([]string, 1000)
+	for i := range stringData {
+		stringData[i] = strconv.Itoa(rand.Intn(100))
+	}
+	ByStringStable(TruncatedKeyStrings{StringSlice(stringData)})
+	if !StringsAreSorted(stringData) {
+		t.Errorf("stable sort didn't sort - 1K truncated strings")
+	}
+}
@@ -0,0 +1,78 @@
+// Copyright 2015 Randall Farmer. All rights reserved.
+
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+package sorts
+
+import "sort"
+
+// SortStats describes how a sort went, for understanding why some data
+// sorts slowly.  See ByUint64Stats.
+type SortStats struct {
+	// Radix is whether the data was radix sorted at all.  It's false if
+	// the data was too small, or already sorted.
+	Radix bool
+	// Presorted is whether the data was found already in order, or in
+	// reverse order and flipped.
+	Presorted bool
+	// Passes counts radix passes: reads through a range of keys to count
+	// them into buckets, including ones that only found identical bits to
+	// skip over.
+	Passes int
+	// QSortRanges counts ranges too small to radix sort that were sorted
+	// by comparison instead, and QSorted the items in them.
+	QSortRanges, QSorted int
+	// Swaps counts calls to data.Swap.
+	Swaps int
+}
+
+// ByUint64Stats sorts data like ByUint64, but in one goroutine, and
+// returns counts of what the sort did.  The sort makes the same moves
+// ByUint64 would, so the counts describe its work too.  Counting costs a
+// little, so use ByUint64 when you don't need them.
+func ByUint64Stats(data Uint64Interface) SortStats {
+	var s SortStats
+	counted := swapCounter{data, &s.Swaps}
+	l := data.Len()
+	if l < qSortCutoff {
+		qSortUint64(counted, 0, l)
+		s.QSortRanges, s.QSorted = 1, l
+		return s
+	}
+	if uint64Presorted(counted, 0, l) {
+		s.Presorted = true
+	} else {
+		s.Radix = true
+		sorter, t := uint64Sorter(counted, 0, l, nil)
+		serialSort(counted, s.wrap(sorter), t)
+	}
+
+	// check results!
+	checkUint64(data, 0, l)
+	return s
+}
+
+// wrap counts the tasks sorter is given into s.
+func (s *SortStats) wrap(sorter sortFunc) sortFunc {
+	return func(data sort.Interface, t task, sortRange func(task)) {
+		if n := t.end - t.pos; n < t.opts.qSortCutoff() {
+			s.QSortRanges++
+			s.QSorted += n
+		} else {
+			s.Passes++
+		}
+		sorter(data, t, sortRange)
+	}
+}
+
+// swapCounter counts a Uint64Interface's Swap calls.
+type swapCounter struct {
+	Uint64Interface
+	swaps *int
+}
+
+func (s swapCounter) Swap(i, j int) {
+	*s.swaps++
+	s.Uint64Interface.Swap(i, j)
+}
@@ -0,0 +1,41 @@
+// Copyright 2015 Randall Farmer. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sorts_test
+
+import (
+	"math/rand"
+	"testing"
+
+	. "github.com/twotwotwo/sorts"
+	. "github.com/twotwotwo/sorts/sortutil"
+)
+
+func TestByUint64Stats(t *testing.T) {
+	a := make([]uint64, 100000)
+	for i := range a {
+		a[i] = uint64(rand.Int63())
+	}
+	s := ByUint64Stats(Uint64Slice(a))
+	if !Uint64sAreSorted(a) {
+		t.Fatalf("not sorted")
+	}
+	if !s.Radix || s.Presorted || s.Passes == 0 || s.QSorted == 0 || s.QSortRanges == 0 || s.Swaps == 0 {
+		t.Errorf("random data: %+v", s)
+	}
+	if s.QSorted > len(a) {
+		t.Errorf("%d items quicksorted, of %d", s.QSorted, len(a))
+	}
+
+	s = ByUint64Stats(Uint64Slice(a))
+	if s.Radix || !s.Presorted || s.Passes != 0 || s.Swaps != 0 {
+		t.Errorf("sorted data: %+v", s)
+	}
+
+	small := []uint64{3, 1, 2}
+	s = ByUint64Stats(Uint64Slice(small))
+	if s.Radix || s.QSortRanges != 1 || s.QSorted != 3 || !Uint64sAreSorted(small) {
+		t.Errorf("small data: %+v", s)
+	}
+}
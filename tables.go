@@ -0,0 +1,59 @@
+// Copyright 2015 Randall Farmer. All rights reserved.
+
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+package sorts
+
+import (
+	"sort"
+	"sync"
+)
+
+// bucketTables are the counts and bucket boundaries for one radix pass.
+// They're 4KB on 64-bit platforms, and normally live on the stack, one set
+// per level of recursion.
+type bucketTables struct {
+	starts, ends [1 << radix]int
+}
+
+// tablePool holds bucketTables for sorts with Options.PoolTables set.
+var tablePool = sync.Pool{New: func() interface{} { return new(bucketTables) }}
+
+// pooledTables turns a radixSort*Tables function into a sortFunc that
+// takes its tables from tablePool, so it uses little stack however deeply
+// it recurses.
+func pooledTables(sorter func(sort.Interface, task, func(task), *bucketTables)) sortFunc {
+	return func(data sort.Interface, t task, sortRange func(task)) {
+		tbl := tablePool.Get().(*bucketTables)
+		*tbl = bucketTables{}
+		sorter(data, t, sortRange, tbl)
+		tablePool.Put(tbl)
+	}
+}
+
+var (
+	pooledRadixSortUint64 = pooledTables(radixSortUint64Tables)
+	pooledRadixSortInt64  = pooledTables(radixSortInt64Tables)
+	pooledRadixSortString = pooledTables(radixSortStringTables)
+	pooledRadixSortBytes  = pooledTables(radixSortBytesTables)
+)
+
+// wideTablePools holds wideRadixSorter's count tables, one pool per radix
+// width; at up to 1MB a set, they're too big for the stack.
+var wideTablePools [maxWideRadix + 1]sync.Pool
+
+// getWideTables returns zeroed bucket starts and ends for a radix of width
+// bits, in one slice of twice the table size.  Hand it back to
+// putWideTables when done.
+func getWideTables(width uint) *[]int {
+	tbl, _ := wideTablePools[width].Get().(*[]int)
+	if tbl == nil {
+		s := make([]int, 2<<width)
+		return &s
+	}
+	clear(*tbl)
+	return tbl
+}
+
+func putWideTables(width uint, tbl *[]int) { wideTablePools[width].Put(tbl) }
@@ -0,0 +1,47 @@
+// Copyright 2015 Randall Farmer. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sorts_test
+
+import (
+	"testing"
+
+	. "github.com/twotwotwo/sorts"
+	. "github.com/twotwotwo/sorts/sortutil"
+)
+
+// untouchable panics if a sort calls any method but Len.
+type untouchable int
+
+func (u untouchable) Len() int           { return int(u) }
+func (u untouchable) Less(i, j int) bool { panic("Less called") }
+func (u untouchable) Swap(i, j int)      { panic("Swap called") }
+func (u untouchable) Key(i int) string   { panic("Key called") }
+
+func TestSortTiny(t *testing.T) {
+	ByString(untouchable(0))
+	ByString(untouchable(1))
+	ByStringRange(untouchable(5), 2, 3)
+}
+
+func benchTiny(b *testing.B, n int) {
+	strs := StringSlice([]string{"b", "a"}[:n])
+	byts := BytesSlice([][]byte{[]byte("b"), []byte("a")}[:n])
+	ints := Uint64Slice([]uint64{2, 1}[:n])
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		ByString(strs)
+		ByBytes(byts)
+		ByUint64(ints)
+		if n == 2 {
+			strs[0], strs[1] = strs[1], strs[0]
+			byts[0], byts[1] = byts[1], byts[0]
+			ints[0], ints[1] = ints[1], ints[0]
+		}
+	}
+}
+
+func BenchmarkSortTiny0(b *testing.B) { benchTiny(b, 0) }
+func BenchmarkSortTiny1(b *testing.B) { benchTiny(b, 1) }
+func BenchmarkSortTiny2(b *testing.B) { benchTiny(b, 2) }
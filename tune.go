@@ -0,0 +1,124 @@
+// Copyright 2015 Randall Farmer. All rights reserved.
+
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+package sorts
+
+import (
+	"runtime"
+	"sync"
+	"time"
+)
+
+// Tuning holds the settings AutoTune picked.
+type Tuning struct {
+	// QSortCutoff is the new size of the smallest range to radix sort.
+	QSortCutoff int
+	// MinParallel is the new size of the smallest collection to sort in
+	// parallel.  It's left alone on machines that can only sort serially.
+	MinParallel int
+}
+
+var (
+	tuneOnce sync.Once
+	tuned    Tuning
+)
+
+// tuneCutoffs and tuneSizes are the QSortCutoff and MinParallel values
+// AutoTune tries.
+var (
+	tuneCutoffs = []int{32, 64, 128, 256, 512}
+	tuneSizes   = []int{1250, 2500, 5000, 10000, 20000, 40000}
+)
+
+const (
+	tuneLen  = 1 << 14 // items sorted to time each cutoff
+	tuneRuns = 5       // runs per setting; the fastest counts
+)
+
+// AutoTune times some small sorts of random uint64s to choose the package
+// defaults for the QSortCutoff and MinParallel settings on this machine,
+// replacing the fixed guesses, which came from one machine.  It takes a
+// few tens of milliseconds, so the package never runs it on its own; call
+// it once near the start of a program, before any sorts start, since it
+// changes package-level settings.  Only the first call measures anything:
+// later ones return its results without timing or changing settings again.
+// The timings are noisy, and other work on the machine can skew them, so
+// benchmark your own data before relying on the results.
+func AutoTune() Tuning {
+	tuneOnce.Do(func() {
+		tuned = Tuning{QSortCutoff: tuneCutoff(), MinParallel: minParallel}
+		qSortCutoff = tuned.QSortCutoff
+		if MaxProcs != 1 && runtime.GOMAXPROCS(0) > 1 {
+			tuned.MinParallel = tuneMinParallel()
+			minParallel = tuned.MinParallel
+		}
+	})
+	return tuned
+}
+
+// tuneCutoff returns whichever of tuneCutoffs sorts tuneLen items
+// fastest, serially.
+func tuneCutoff() int {
+	src := tuneData(tuneLen)
+	best, bestTime := qSortCutoff, time.Duration(0)
+	for _, c := range tuneCutoffs {
+		d := timeSort(src, Options{QSortCutoff: c, MaxProcs: 1})
+		if bestTime == 0 || d < bestTime {
+			best, bestTime = c, d
+		}
+	}
+	return best
+}
+
+// tuneMinParallel returns the smallest of tuneSizes that sorts at least
+// as fast in parallel as serially, or the largest if none does.
+func tuneMinParallel() int {
+	src := tuneData(tuneSizes[len(tuneSizes)-1])
+	for _, n := range tuneSizes {
+		serial := timeSort(src[:n], Options{QSortCutoff: qSortCutoff, MaxProcs: 1})
+		parallel := timeSort(src[:n], Options{QSortCutoff: qSortCutoff, MinParallel: 1})
+		if parallel <= serial {
+			return n
+		}
+	}
+	return tuneSizes[len(tuneSizes)-1]
+}
+
+// timeSort returns the fastest of tuneRuns sorts of copies of src.
+func timeSort(src []uint64, opts Options) time.Duration {
+	data := make(tuneSlice, len(src))
+	best := time.Duration(0)
+	for i := 0; i < tuneRuns; i++ {
+		copy(data, src)
+		start := time.Now()
+		ByUint64With(data, opts)
+		if d := time.Since(start); i == 0 || d < best {
+			best = d
+		}
+	}
+	return best
+}
+
+// tuneData returns n pseudorandom uint64s from a fixed seed, so every
+// setting is timed on the same data.
+func tuneData(n int) []uint64 {
+	data := make([]uint64, n)
+	x := uint64(88172645463325252)
+	for i := range data {
+		x ^= x << 13
+		x ^= x >> 7
+		x ^= x << 17
+		data[i] = x
+	}
+	return data
+}
+
+// tuneSlice is the Uint64Interface AutoTune sorts.
+type tuneSlice []uint64
+
+func (p tuneSlice) Len() int           { return len(p) }
+func (p tuneSlice) Less(i, j int) bool { return p[i] < p[j] }
+func (p tuneSlice) Swap(i, j int)      { p[i], p[j] = p[j], p[i] }
+func (p tuneSlice) Key(i int) uint64   { return p[i] }
@@ -0,0 +1,59 @@
+// Copyright 2015 Randall Farmer. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sorts_test
+
+import (
+	"testing"
+
+	. "github.com/twotwotwo/sorts"
+	. "github.com/twotwotwo/sorts/sortutil"
+)
+
+func TestAutoTune(t *testing.T) {
+	ForgetAutoTune() // in case of -count or -cpu running this again
+	origCutoff, origParallel := SetQSortCutoff(0), SetMinParallel(0)
+	SetQSortCutoff(origCutoff)
+	SetMinParallel(origParallel)
+	defer func() {
+		SetQSortCutoff(origCutoff)
+		SetMinParallel(origParallel)
+	}()
+
+	tuning := AutoTune()
+	if tuning.QSortCutoff < 32 || tuning.QSortCutoff > 512 {
+		t.Errorf("QSortCutoff %d outside the range tried", tuning.QSortCutoff)
+	}
+	if tuning.MinParallel < 1 {
+		t.Errorf("MinParallel %d < 1", tuning.MinParallel)
+	}
+	if got := SetQSortCutoff(origCutoff); got != tuning.QSortCutoff {
+		t.Errorf("package cutoff %d, AutoTune returned %d", got, tuning.QSortCutoff)
+	}
+	if got := SetMinParallel(origParallel); got != tuning.MinParallel {
+		t.Errorf("package MinParallel %d, AutoTune returned %d", got, tuning.MinParallel)
+	}
+
+	// later calls return the cached result and leave settings alone
+	if again := AutoTune(); again != tuning {
+		t.Errorf("second AutoTune returned %+v, first %+v", again, tuning)
+	}
+	if got := SetQSortCutoff(origCutoff); got != origCutoff {
+		t.Errorf("second AutoTune changed the cutoff to %d", got)
+	}
+	if got := SetMinParallel(origParallel); got != origParallel {
+		t.Errorf("second AutoTune changed MinParallel to %d", got)
+	}
+
+	data := make([]uint64, 1e5)
+	for i := range data {
+		data[i] = uint64(i*7919) % 100003
+	}
+	SetQSortCutoff(tuning.QSortCutoff)
+	SetMinParallel(tuning.MinParallel)
+	ByUint64(Uint64Slice(data))
+	if !Uint64sAreSorted(data) {
+		t.Error("not sorted after AutoTune")
+	}
+}
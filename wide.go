@@ -0,0 +1,213 @@
+// Copyright 2015 Randall Farmer. All rights reserved.
+
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+package sorts
+
+import "sort"
+
+// maxWideRadix is the widest radix PreferFewerPasses will use; it means
+// 64K-entry count tables.
+const maxWideRadix = 16
+
+// wideRadix picks the fewest passes of up to maxWideRadix bits that cover
+// the estimated key range of data[a:b], spreading the bits evenly over the
+// passes so count tables are no bigger than they need to be, and returns
+// the radix width and starting shift.  It never picks a table with more
+// entries than the range has items.  A width of radix or less means to use
+// the usual sort.
+func wideRadix(data Uint64Interface, a, b int) (width uint, shift int) {
+	bits := guessIntBits(data, a, b)
+	passes := (bits + maxWideRadix - 1) / maxWideRadix
+	w := 0
+	if passes > 0 {
+		w = (bits + passes - 1) / passes
+	}
+	for w > radix && 1<<uint(w) > b-a {
+		w--
+	}
+	if w <= radix {
+		return radix, 0
+	}
+	return uint(w), bits - w
+}
+
+// maxCountingRadix is the widest radix countingRadix picks.  Wider count
+// tables (and 64K buckets to scatter into) miss cache enough that one
+// 16-bit pass took 2.5x as long as two 8-bit ones on 1M keys; 12 bits
+// still beat two passes by 15-20%.
+const maxCountingRadix = 12
+
+// countingRadix reports whether the keys of data[a:b] look to vary only in
+// their low bits, more than radix of them but no more than
+// maxCountingRadix, and there are at least as many items as possible keys.
+// Then one wide counting pass of the returned width can finish what would
+// take the usual sort two.  Small-range keys like shuffled indices or enum
+// codes hit this.
+func countingRadix(data Uint64Interface, a, b int) (width uint, ok bool) {
+	bits := guessIntBits(data, a, b)
+	if bits <= radix || bits > maxCountingRadix || 1<<uint(bits) > b-a {
+		return 0, false
+	}
+	return uint(bits), true
+}
+
+// ByUint64Radix sorts data by a uint64 key like ByUint64, but with a radix
+// of bits bits, from 1 to 16, instead of choosing one.  Narrow radixes mean
+// small count tables but more passes; wide ones mean fewer passes over
+// tables that may not fit in cache.  The usual 8 bits is hard to beat for
+// most data, so benchmark before using this.
+func ByUint64Radix(data Uint64Interface, bits int) {
+	checkRadixBits(bits)
+	a, b := 0, data.Len()
+	if b-a < qSortCutoff {
+		qSortUint64(data, a, b)
+		return
+	}
+	parallelSort(data, wideRadixSorter(uint(bits)), radixTask(data, a, b, bits))
+	checkUint64(data, a, b)
+}
+
+// ByInt64Radix is ByUint64Radix for an int64 key.
+func ByInt64Radix(data Int64Interface, bits int) {
+	checkRadixBits(bits)
+	a, b := 0, data.Len()
+	if b-a < qSortCutoff {
+		qSortInt64(data, a, b)
+		return
+	}
+	parallelSort(data, wideRadixSorter(uint(bits)), radixTask(intwrapper{data}, a, b, bits))
+	checkInt64(data, a, b)
+}
+
+func checkRadixBits(bits int) {
+	if bits < 1 || bits > maxWideRadix {
+		panic("sorts: radix must be 1 to 16 bits")
+	}
+}
+
+// radixTask is the first task of a sort of data[a:b] with a radix of bits
+// bits, covering the top bits of the estimated key range.
+func radixTask(data Uint64Interface, a, b, bits int) task {
+	shift := guessIntBits(data, a, b) - bits
+	if shift < 0 {
+		shift = 0
+	}
+	return task{offs: shift, pos: a, end: b}
+}
+
+// wideRadixSorter returns a sortFunc like radixSortUint64 but with a radix
+// of width bits, which can also be narrower than radix.  Ranges too small
+// to fill the count table, or under QSortCutoff, are handed to
+// radixSortUint64, whose subtasks come back here to be checked again.
+// Int64Interface data is sorted through an intwrapper.
+func wideRadixSorter(width uint) sortFunc {
+	wideMask := uint64(1)<<width - 1
+	return func(dataI sort.Interface, t task, sortRange func(task)) {
+		data, ok := dataI.(Uint64Interface)
+		if !ok {
+			data = intwrapper{dataI.(Int64Interface)}
+		}
+		shift, a, b := uint(t.offs), t.pos, t.end
+		if b-a < 1<<width || b-a < t.opts.qSortCutoff() {
+			if t.opts.poolTables() {
+				pooledRadixSortUint64(data, t, sortRange)
+				return
+			}
+			radixSortUint64(data, t, sortRange)
+			return
+		}
+
+		// same as radixSortUint64, but the tables are too big for the
+		// stack
+		tbl := getWideTables(width)
+		defer putWideTables(width, tbl)
+		bucketStarts, bucketEnds := (*tbl)[:1<<width], (*tbl)[1<<width:]
+		min := data.Key(a)
+		max := min
+		for i := a; i < b; i++ {
+			k := data.Key(i)
+			bucketStarts[(k>>shift)&wideMask]++
+			if k < min {
+				min = k
+			}
+			if k > max {
+				max = k
+			}
+		}
+
+		// skip past common prefixes, bail if all keys equal
+		diff := min ^ max
+		if diff == 0 {
+			qSortEqualKeyRange(data, a, b)
+			return
+		}
+		if diff>>shift == 0 || diff>>(shift+width) != 0 {
+			// find highest 1 bit in diff
+			log2diff := 0
+			for diff != 0 {
+				log2diff++
+				diff >>= 1
+			}
+			nextShift := log2diff - int(width)
+			if nextShift < 0 {
+				nextShift = 0
+			}
+			sortRange(task{offs: nextShift, pos: a, end: b, opts: t.opts})
+			return
+		}
+
+		scatterUint64(data, a, shift, wideMask, bucketStarts, bucketEnds)
+
+		if shift == 0 {
+			pos := a
+			for _, end := range bucketEnds {
+				if end > pos+1 {
+					qSortEqualKeyRange(data, pos, end)
+				}
+				pos = end
+			}
+			return
+		}
+
+		nextShift := shift - width
+		if shift < width {
+			nextShift = 0
+		}
+		pos := a
+		for _, end := range bucketEnds {
+			if end > pos+1 {
+				sortRange(task{offs: int(nextShift), pos: pos, end: end, opts: t.opts})
+			}
+			pos = end
+		}
+	}
+}
+
+// scatterUint64 moves the items of data starting at a into the buckets
+// that bits shift and up of their keys, masked by mask, pick, given each
+// bucket's item count in bucketStarts.  It leaves bucketEnds holding where
+// each bucket ends, and bucketStarts equal to bucketEnds.
+func scatterUint64(data Uint64Interface, a int, shift uint, mask uint64, bucketStarts, bucketEnds []int) {
+	pos := a
+	for i, c := range bucketStarts {
+		bucketStarts[i] = pos
+		pos += c
+		bucketEnds[i] = pos
+	}
+
+	for curBucket, bucketEnd := range bucketEnds {
+		i := bucketStarts[curBucket]
+		for i < bucketEnd {
+			destBucket := (data.Key(i) >> shift) & mask
+			if destBucket == uint64(curBucket) {
+				i++
+				bucketStarts[destBucket]++
+				continue
+			}
+			data.Swap(i, bucketStarts[destBucket])
+			bucketStarts[destBucket]++
+		}
+	}
+}
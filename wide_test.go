@@ -0,0 +1,167 @@
+// Copyright 2015 Randall Farmer. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sorts_test
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+
+	. "github.com/twotwotwo/sorts"
+	. "github.com/twotwotwo/sorts/sortutil"
+)
+
+// preferFewerPasses runs a function with PreferFewerPasses set.
+func preferFewerPasses(f func()) {
+	defer func(old bool) { PreferFewerPasses = old }(PreferFewerPasses)
+	PreferFewerPasses = true
+	f()
+}
+
+func TestPreferFewerPasses(t *testing.T) {
+	n := 200000
+	if testing.Short() {
+		n /= 10
+	}
+	uints := make([]uint64, n)
+	ints := make([]int64, n)
+	for i := range uints {
+		uints[i] = uint64(rand.Uint32())
+		ints[i] = rand.Int63n(1<<40) - 1<<39
+	}
+	// a few items in a narrow range, to exercise the fallback to the
+	// 8-bit radix
+	narrow := make([]uint64, n)
+	for i := range narrow {
+		narrow[i] = uint64(rand.Intn(300))
+	}
+	preferFewerPasses(func() {
+		Uint64s(uints)
+		Int64s(ints)
+		Uint64s(narrow)
+	})
+	if !Uint64sAreSorted(uints) {
+		t.Errorf("32-bit uints didn't sort with PreferFewerPasses")
+	}
+	if !Int64sAreSorted(ints) {
+		t.Errorf("signed ints didn't sort with PreferFewerPasses")
+	}
+	if !Uint64sAreSorted(narrow) {
+		t.Errorf("narrow-range uints didn't sort with PreferFewerPasses")
+	}
+
+	// all the usual data, for what it's worth
+	preferFewerPasses(func() {
+		testBentleyMcIlroy(t, byInt64Wrapper, func(n int) int { return n * lg(n) * 12 / 10 })
+	})
+}
+
+func TestByUint64Radix(t *testing.T) {
+	n := 100000
+	if testing.Short() {
+		n /= 10
+	}
+	for _, bits := range []int{1, 4, 8, 11, 16} {
+		uints := make([]uint64, n)
+		ints := make([]int64, n)
+		for i := range uints {
+			uints[i] = uint64(rand.Uint32())
+			ints[i] = rand.Int63n(1<<40) - 1<<39
+		}
+		ByUint64Radix(Uint64Slice(uints), bits)
+		ByInt64Radix(Int64Slice(ints), bits)
+		if !Uint64sAreSorted(uints) {
+			t.Errorf("uints didn't sort with a %d-bit radix", bits)
+		}
+		if !Int64sAreSorted(ints) {
+			t.Errorf("ints didn't sort with a %d-bit radix", bits)
+		}
+	}
+	testBentleyMcIlroy(t, func(data sort.Interface) { ByInt64Radix(data.(Int64Interface), 4) }, func(n int) int { return n * lg(n) * 12 / 10 })
+}
+
+func TestSmallRange(t *testing.T) {
+	for _, keyRange := range []int{1 << 9, 1 << 12} {
+		uints := make([]uint64, 100000)
+		ints := make([]int64, len(uints))
+		for i := range uints {
+			uints[i] = 1<<40 + uint64(rand.Intn(keyRange))
+			ints[i] = -1<<40 + int64(rand.Intn(keyRange))
+		}
+		uints[len(uints)/2+1] = 0 // an outlier sampling misses
+		Uint64s(uints)
+		Int64s(ints)
+		if !Uint64sAreSorted(uints) || !Int64sAreSorted(ints) {
+			t.Errorf("keys in a range of %d didn't sort", keyRange)
+		}
+	}
+}
+
+func benchUint32Range(b *testing.B, f func([]uint64)) {
+	b.StopTimer()
+	data := make([]uint64, 1e6)
+	for i := 0; i < b.N; i++ {
+		for i := range data {
+			data[i] = uint64(rand.Uint32())
+		}
+		b.StartTimer()
+		f(data)
+		b.StopTimer()
+	}
+}
+
+func BenchmarkSortUint32Range1e6(b *testing.B) { benchUint32Range(b, Uint64s) }
+
+func BenchmarkSortUint32Range1e6FewerPasses(b *testing.B) {
+	preferFewerPasses(func() { benchUint32Range(b, Uint64s) })
+}
+
+func benchRadix(bits int) func([]uint64) {
+	return func(a []uint64) { ByUint64Radix(Uint64Slice(a), bits) }
+}
+
+func BenchmarkSortUint32Range1e6Radix4(b *testing.B)  { benchUint32Range(b, benchRadix(4)) }
+func BenchmarkSortUint32Range1e6Radix8(b *testing.B)  { benchUint32Range(b, benchRadix(8)) }
+func BenchmarkSortUint32Range1e6Radix11(b *testing.B) { benchUint32Range(b, benchRadix(11)) }
+
+func benchSmallRange(b *testing.B, n, keyRange int) {
+	b.StopTimer()
+	data := make([]uint64, n)
+	for i := 0; i < b.N; i++ {
+		for i := range data {
+			data[i] = uint64(rand.Intn(keyRange))
+		}
+		b.StartTimer()
+		Uint64s(data)
+		b.StopTimer()
+	}
+}
+
+func BenchmarkSortRange1K1e6(b *testing.B)  { benchSmallRange(b, 1e6, 1<<10) }
+func BenchmarkSortRange4K1e6(b *testing.B)  { benchSmallRange(b, 1e6, 1<<12) }
+func BenchmarkSortRange4K1e5(b *testing.B)  { benchSmallRange(b, 1e5, 1<<12) }
+func BenchmarkSortRange64K1e6(b *testing.B) { benchSmallRange(b, 1e6, 1<<16) }
+
+func TestByUint64RadixAllocs(t *testing.T) {
+	defer func(old int) { MaxProcs = old }(MaxProcs)
+	MaxProcs = 1
+	src := make([]uint64, 10000)
+	for i := range src {
+		src[i] = uint64(rand.Uint32())
+	}
+	data := make(Uint64Slice, len(src))
+	for _, bits := range []int{4, 11} {
+		allocs := testing.AllocsPerRun(10, func() {
+			copy(data, src)
+			ByUint64Radix(data, bits)
+		})
+		// the count tables come from a pool, so a sort needs only a
+		// few allocations, not two per task (hundreds here); the race
+		// detector makes the pool drop some, so allow a few dozen
+		if allocs > 50 {
+			t.Errorf("%d-bit radix: %v allocations per sort", bits, allocs)
+		}
+	}
+}